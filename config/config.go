@@ -3,17 +3,58 @@ package config
 import (
 	"fmt"
 	"log"
+	"time"
 
+	"github.com/digisata/todo-service/pkg/blobstore"
 	"github.com/digisata/todo-service/pkg/grpcserver"
+	"github.com/digisata/todo-service/pkg/healthcheck"
+	"github.com/digisata/todo-service/pkg/httpserver"
+	"github.com/digisata/todo-service/pkg/logging"
+	"github.com/digisata/todo-service/pkg/metrics"
 	"github.com/digisata/todo-service/pkg/postgres"
+	"github.com/digisata/todo-service/pkg/ratelimit"
+	"github.com/digisata/todo-service/pkg/tracing"
 	"github.com/spf13/viper"
 )
 
-type Config struct {
-	AppEnv     string            `mapstructure:"app_env"`
-	Postgres   postgres.Config   `mapstructure:"postgres"`
-	GrpcServer grpcserver.Config `mapstructure:"grpc_server"`
-}
+type (
+	Config struct {
+		AppEnv string `mapstructure:"app_env"`
+		// ShutdownDelay is how long the servers keep accepting requests
+		// after health checks report NOT_SERVING, so load balancers stop
+		// routing first; 0 stops them at once.
+		ShutdownDelay time.Duration `mapstructure:"shutdown_delay"`
+		// ShutdownTimeout bounds how long in-flight requests are drained
+		// after that; 20 seconds when unset. Keep both together below the
+		// termination grace period of the orchestrator.
+		ShutdownTimeout time.Duration      `mapstructure:"shutdown_timeout"`
+		Postgres        postgres.Config    `mapstructure:"postgres"`
+		GrpcServer      grpcserver.Config  `mapstructure:"grpc_server"`
+		HttpServer      httpserver.Config  `mapstructure:"http_server"`
+		Attachment      Attachment         `mapstructure:"attachment"`
+		Health          healthcheck.Config `mapstructure:"health"`
+		Metrics         metrics.Config     `mapstructure:"metrics"`
+		Tracing         tracing.Config     `mapstructure:"tracing"`
+		Logging         logging.Config     `mapstructure:"logging"`
+		RateLimit       ratelimit.Config   `mapstructure:"rate_limit"`
+		Quota           Quota              `mapstructure:"quota"`
+	}
+
+	// Quota limits what a user can store; 0 means no limit. Activities
+	// count per user, tasks per activity and the note size is in bytes of
+	// stored HTML.
+	Quota struct {
+		MaxActivities       int `mapstructure:"max_activities"`
+		MaxTasksPerActivity int `mapstructure:"max_tasks_per_activity"`
+		MaxNoteSize         int `mapstructure:"max_note_size"`
+	}
+
+	Attachment struct {
+		// MaxSize is in bytes; 10 MiB when unset.
+		MaxSize int64            `mapstructure:"max_size"`
+		Store   blobstore.Config `mapstructure:"store"`
+	}
+)
 
 func Load() (*Config, error) {
 	var cfg Config
@@ -31,6 +72,11 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("environment can't be loaded: %v", err)
 	}
 
+	err = cfg.GrpcServer.Web.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid grpc_server.web: %v", err)
+	}
+
 	log.Printf("The App is running in %s environment", cfg.AppEnv)
 
 	return &cfg, nil
@@ -0,0 +1,8 @@
+// Package openapi holds the OpenAPI document generated from the HTTP
+// annotations of the protos by script/proto-gen.sh.
+package openapi
+
+import _ "embed"
+
+//go:embed todo-service.swagger.json
+var Document []byte
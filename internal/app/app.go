@@ -4,80 +4,347 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/digisata/todo-service/config"
+	"github.com/digisata/todo-service/docs/openapi"
+	"github.com/digisata/todo-service/internal/entity"
 	"github.com/digisata/todo-service/internal/handler"
 	"github.com/digisata/todo-service/internal/repository"
 	"github.com/digisata/todo-service/internal/usecase"
+	"github.com/digisata/todo-service/pkg/blobstore"
+	"github.com/digisata/todo-service/pkg/constans"
+	"github.com/digisata/todo-service/pkg/gateway"
 	"github.com/digisata/todo-service/pkg/grpcserver"
+	"github.com/digisata/todo-service/pkg/healthcheck"
+	"github.com/digisata/todo-service/pkg/httpserver"
 	"github.com/digisata/todo-service/pkg/interceptor"
+	"github.com/digisata/todo-service/pkg/logging"
+	"github.com/digisata/todo-service/pkg/metrics"
 	"github.com/digisata/todo-service/pkg/postgres"
+	"github.com/digisata/todo-service/pkg/ratelimit"
+	"github.com/digisata/todo-service/pkg/tracing"
 	activityPB "github.com/digisata/todo-service/stubs/activity"
+	attachmentPB "github.com/digisata/todo-service/stubs/attachment"
+	calendarPB "github.com/digisata/todo-service/stubs/calendar"
+	commentPB "github.com/digisata/todo-service/stubs/comment"
+	syncPB "github.com/digisata/todo-service/stubs/sync"
 	taskPB "github.com/digisata/todo-service/stubs/task"
 	textPB "github.com/digisata/todo-service/stubs/text"
+	transferPB "github.com/digisata/todo-service/stubs/transfer"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
 	"go.uber.org/zap"
-	"google.golang.org/grpc/health"
+	channelzService "google.golang.org/grpc/channelz/service"
 	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+const (
+	defaultShutdownTimeout = 20 * time.Second
+	tracingFlushTimeout    = 5 * time.Second
 )
 
 func Run(cfg *config.Config) {
-	ctx := context.Background()
+	// ctx lives until the servers are drained: the gateway needs its
+	// connection while in-flight requests finish. Only the signal context
+	// ends on SIGINT or SIGTERM.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 
-	// Setup DB
-	dbUsername := cfg.Postgres.DbUser
-	dbPassword := cfg.Postgres.DbPass
-	dbHost := cfg.Postgres.DbHost
-	dbPort := cfg.Postgres.DbPort
-	dbName := cfg.Postgres.DbName
+	signalCtx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	logger, _ := zap.NewProduction()
+	logger, err := logging.New(cfg.Logging)
+	if err != nil {
+		log.Fatalf("app - run - logging.New: %v", err.Error())
+	}
 	defer logger.Sync()
 
 	sugar := logger.Sugar()
 
-	postgresUrl := fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=disable", dbUsername, dbPassword, dbHost, dbPort, dbName)
+	shutdownTracing, err := tracing.New(ctx, cfg.Tracing)
+	if err != nil {
+		log.Fatalf("app - run - tracing.New: %v", err.Error())
+	}
+
+	// Setup DB
+	postgresUrl := postgresURL(cfg)
 
 	pg, err := postgres.New(postgresUrl)
 	if err != nil {
 		log.Fatalf("app - run - maria.New: %v", err.Error())
 	}
-	defer pg.Close()
 
 	err = RunMigrate(pg.Db)
 	if err != nil {
 		log.Fatalf("Failed to migrate database: %v", err.Error())
 	}
 
+	notifier, err := postgres.NewNotifier(postgresUrl, "activity_events")
+	if err != nil {
+		log.Fatalf("app - run - postgres.NewNotifier: %v", err.Error())
+	}
+
+	healthCheck := healthcheck.New(cfg.Health, sugar)
+	healthCheck.AddCheck("postgres", pg.Ping)
+	healthCheck.AddService(taskPB.TaskService_ServiceDesc.ServiceName, "postgres")
+	healthCheck.AddService(activityPB.ActivityService_ServiceDesc.ServiceName, "postgres")
+	healthCheck.AddService(textPB.TextService_ServiceDesc.ServiceName, "postgres")
+
+	var workers sync.WaitGroup
+	workers.Add(2)
+	go func() {
+		defer workers.Done()
+		notifier.Run(ctx)
+	}()
+
+	go func() {
+		defer workers.Done()
+		healthCheck.Run(ctx)
+	}()
+
+	blobStore, err := blobstore.New(cfg.Attachment.Store)
+	if err != nil {
+		log.Fatalf("app - run - blobstore.New: %v", err.Error())
+	}
+
 	// Dependencies injection
-	taskRepository := repository.NewTask(pg)
-	taskService := usecase.NewTask(taskRepository)
-	taskHandler := handler.NewTask(taskService)
+	quota := entity.Quota{
+		MaxActivities:       cfg.Quota.MaxActivities,
+		MaxTasksPerActivity: cfg.Quota.MaxTasksPerActivity,
+		MaxNoteSize:         cfg.Quota.MaxNoteSize,
+	}
+
+	eventRepository := repository.NewEvent(pg)
 
 	activityRepository := repository.NewActivity(pg)
-	activityService := usecase.NewActivity(activityRepository)
+	activityService := usecase.NewActivity(activityRepository, eventRepository, notifier, pg, quota)
 	activityCategoryHandler := handler.NewActivity(activityService)
 
+	taskRepository := repository.NewTask(pg)
+	taskService := usecase.NewTask(taskRepository, activityRepository, pg, quota)
+	taskHandler := handler.NewTask(taskService)
+
 	textRepository := repository.NewText(pg)
-	textService := usecase.NewText(textRepository)
+	textService := usecase.NewText(textRepository, activityRepository, quota)
 	textHandler := handler.NewText(textService)
 
+	err = textService.SanitizeStoredTexts(ctx)
+	if err != nil {
+		log.Fatalf("app - run - textService.SanitizeStoredTexts: %v", err.Error())
+	}
+
+	syncRepository := repository.NewSync(pg)
+	syncService := usecase.NewSync(syncRepository, activityRepository, taskRepository, pg, quota)
+	syncHandler := handler.NewSync(syncService)
+
+	attachmentRepository := repository.NewAttachment(pg)
+	attachmentService := usecase.NewAttachment(attachmentRepository, taskRepository, textRepository, blobStore, cfg.Attachment.MaxSize)
+	attachmentHandler := handler.NewAttachment(attachmentService)
+
+	commentRepository := repository.NewComment(pg)
+	commentService := usecase.NewComment(commentRepository, taskRepository)
+	commentHandler := handler.NewComment(commentService)
+
+	transferService := usecase.NewTransfer(activityRepository, taskRepository, textRepository, pg, quota)
+	transferHandler := handler.NewTransfer(transferService)
+
+	feedTokenRepository := repository.NewFeedToken(pg)
+	calendarService := usecase.NewCalendar(feedTokenRepository, activityRepository, taskRepository, pg, cfg.HttpServer.URL(), quota)
+	calendarHandler := handler.NewCalendar(calendarService)
+	calendarHTTPHandler := handler.NewCalendarHTTP(calendarService)
+
+	// Setup http server for calendar apps and the json gateway
+	mux := http.NewServeMux()
+	healthCheck.Register(mux)
+	calendarHTTPHandler.Register(mux)
+
+	// The gateway calls the gRPC server with its own client certificate, so
+	// it would let plain HTTP callers past a server that requires one.
+	if cfg.GrpcServer.RequiresClientCert() {
+		sugar.Warnw(constans.WARN,
+			"message", "JSON gateway disabled, the gRPC server requires client certificates",
+		)
+	} else {
+		gatewayCreds, err := grpcserver.ClientCredentials(cfg.GrpcServer)
+		if err != nil {
+			log.Fatalf("app - run - grpcserver.ClientCredentials: %v", err.Error())
+		}
+
+		gatewayMux, err := gateway.New(ctx, cfg.GrpcServer.Endpoint(), gatewayCreds,
+			taskPB.RegisterTaskServiceHandlerFromEndpoint,
+			activityPB.RegisterActivityServiceHandlerFromEndpoint,
+			textPB.RegisterTextServiceHandlerFromEndpoint,
+		)
+		if err != nil {
+			log.Fatalf("app - run - gateway.New: %v", err.Error())
+		}
+
+		mux.Handle("/v1/", gatewayMux)
+		mux.HandleFunc("GET /openapi.json", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write(openapi.Document)
+		})
+	}
+
+	serverErr := make(chan error, 3)
+
+	// Setup metrics server, it stays up while the others drain
+	prometheus.MustRegister(collectors.NewDBStatsCollector(pg.Db, cfg.Postgres.DbName))
+
+	var metricsServer *metrics.MetricsServer
+	if cfg.Metrics.Address != "" {
+		metricsServer = metrics.NewMetricsServer(cfg.Metrics)
+		go func() {
+			err := metricsServer.Run()
+			if err != nil {
+				serverErr <- fmt.Errorf("metricsServer.Run: %w", err)
+			}
+		}()
+	}
+
+	httpServer := httpserver.NewHttpServer(cfg.HttpServer, mux)
+	go func() {
+		err := httpServer.Run()
+		if err != nil {
+			serverErr <- fmt.Errorf("httpServer.Run: %w", err)
+		}
+	}()
+
 	// Setup grpc server
-	im := interceptor.NewInterceptorManager(sugar)
+	var limiter *ratelimit.Limiter
+	if cfg.RateLimit.Enabled {
+		limiter, err = ratelimit.New(cfg.RateLimit)
+		if err != nil {
+			log.Fatalf("app - run - ratelimit.New: %v", err.Error())
+		}
+	}
+
+	im := interceptor.NewInterceptorManager(sugar, cfg.Logging, limiter)
 	grpcServer, err := grpcserver.NewGrpcServer(cfg.GrpcServer, sugar, im)
 	if err != nil {
 		panic(err)
 	}
-	defer grpcServer.Stop(ctx)
 
 	taskPB.RegisterTaskServiceServer(grpcServer, taskHandler)
 	activityPB.RegisterActivityServiceServer(grpcServer, activityCategoryHandler)
 	textPB.RegisterTextServiceServer(grpcServer, textHandler)
-	grpc_health_v1.RegisterHealthServer(grpcServer.Server, health.NewServer())
+	syncPB.RegisterSyncServiceServer(grpcServer, syncHandler)
+	attachmentPB.RegisterAttachmentServiceServer(grpcServer, attachmentHandler)
+	commentPB.RegisterCommentServiceServer(grpcServer, commentHandler)
+	transferPB.RegisterTransferServiceServer(grpcServer, transferHandler)
+	calendarPB.RegisterCalendarServiceServer(grpcServer, calendarHandler)
+	grpc_health_v1.RegisterHealthServer(grpcServer.Server, healthCheck.Server())
 
-	err = grpcServer.Run()
+	// Debugging services, refused by the server to callers outside the
+	// debug allowlist
+	if cfg.GrpcServer.Debug.Reflection {
+		reflection.Register(grpcServer.Server)
+	}
+
+	if cfg.GrpcServer.Debug.Channelz {
+		channelzService.RegisterChannelzServiceToServer(grpcServer.Server)
+	}
+
+	go func() {
+		err := grpcServer.Run()
+		if err != nil {
+			serverErr <- fmt.Errorf("grpcServer.Run: %w", err)
+		}
+	}()
+
+	select {
+	case <-signalCtx.Done():
+		log.Println("Shutting down...")
+	case err := <-serverErr:
+		sugar.Errorw(constans.ERROR,
+			"error", err.Error(),
+		)
+	}
+
+	shutdown(cfg, sugar, healthCheck, grpcServer, httpServer)
+
+	cancel()
+	workers.Wait()
+
+	if metricsServer != nil {
+		metricsServer.Close()
+	}
+
+	err = notifier.Close()
 	if err != nil {
-		panic(err)
+		sugar.Errorw(constans.ERROR,
+			"error", err.Error(),
+		)
 	}
 
-	<-ctx.Done()
+	pg.Close()
+
+	// Spans of the drained requests are still buffered
+	flushCtx, cancelFlush := context.WithTimeout(context.Background(), tracingFlushTimeout)
+	defer cancelFlush()
+
+	err = shutdownTracing(flushCtx)
+	if err != nil {
+		sugar.Errorw(constans.ERROR,
+			"error", err.Error(),
+		)
+	}
+}
+
+// shutdown takes the service out of rotation and drains both servers within
+// the configured timeout. The servers keep accepting requests for the
+// configured delay first, until load balancers watching the health service
+// have stopped routing to them, so the requests left are the ones already in
+// flight. Watch and collaboration streams do not wait for the timeout, they
+// end as soon as draining starts.
+func shutdown(cfg *config.Config, logger *zap.SugaredLogger, healthCheck *healthcheck.Health, grpcServer *grpcserver.GrpcServer, httpServer *httpserver.HttpServer) {
+	healthCheck.Shutdown()
+
+	if cfg.ShutdownDelay > 0 {
+		logger.Infow(constans.INFO,
+			"message", "waiting for load balancers before draining",
+			"delay", cfg.ShutdownDelay.String(),
+		)
+		time.Sleep(cfg.ShutdownDelay)
+	}
+
+	timeout := cfg.ShutdownTimeout
+	if timeout <= 0 {
+		timeout = defaultShutdownTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		grpcServer.Stop(ctx)
+	}()
+
+	go func() {
+		defer wg.Done()
+
+		err := httpServer.Stop(ctx)
+		if err != nil {
+			logger.Errorw(constans.ERROR,
+				"error", err.Error(),
+			)
+		}
+	}()
+
+	wg.Wait()
+}
+
+func postgresURL(cfg *config.Config) string {
+	return fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=disable", cfg.Postgres.DbUser, cfg.Postgres.DbPass, cfg.Postgres.DbHost, cfg.Postgres.DbPort, cfg.Postgres.DbName)
 }
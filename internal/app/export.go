@@ -0,0 +1,80 @@
+package app
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/digisata/todo-service/config"
+	"github.com/digisata/todo-service/internal/constant"
+	"github.com/digisata/todo-service/internal/entity"
+	"github.com/digisata/todo-service/internal/repository"
+	"github.com/digisata/todo-service/internal/usecase"
+	"github.com/digisata/todo-service/pkg/postgres"
+)
+
+// RunExport exports activities from the command line, to stdout unless -o
+// is given:
+//
+//	todo-service export -format json [-activity ID] [-include-archived] [-o FILE]
+func RunExport(cfg *config.Config, args []string) (err error) {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	format := flags.String("format", constant.EXPORT_FORMAT_JSON, strings.Join([]string{
+		constant.EXPORT_FORMAT_JSON,
+		constant.EXPORT_FORMAT_CSV,
+		constant.EXPORT_FORMAT_MARKDOWN,
+		constant.EXPORT_FORMAT_ICAL,
+	}, ", "))
+	activityID := flags.String("activity", "", "id of the activity to export (default: all)")
+	includeArchived := flags.Bool("include-archived", false, "also export archived activities and tasks")
+	output := flags.String("o", "", "file to write (default: stdout)")
+
+	err = flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	if flags.NArg() != 0 {
+		return fmt.Errorf("usage: export [-format FORMAT] [-activity ID] [-include-archived] [-o FILE]")
+	}
+
+	var out io.Writer = os.Stdout
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			closeErr := file.Close()
+			if err == nil {
+				err = closeErr
+			}
+		}()
+
+		out = file
+	}
+
+	pg, err := postgres.New(postgresURL(cfg))
+	if err != nil {
+		return err
+	}
+	defer pg.Close()
+
+	transferService := usecase.NewTransfer(repository.NewActivity(pg), repository.NewTask(pg), repository.NewText(pg), pg, entity.Quota{})
+
+	w := bufio.NewWriter(out)
+	err = transferService.Export(context.Background(), entity.ExportRequest{
+		Format:          *format,
+		ActivityID:      *activityID,
+		IncludeArchived: *includeArchived,
+	}, w)
+	if err != nil {
+		return err
+	}
+
+	return w.Flush()
+}
@@ -0,0 +1,91 @@
+package app
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/digisata/todo-service/config"
+	"github.com/digisata/todo-service/internal/constant"
+	"github.com/digisata/todo-service/internal/entity"
+	"github.com/digisata/todo-service/internal/repository"
+	"github.com/digisata/todo-service/internal/usecase"
+	"github.com/digisata/todo-service/pkg/postgres"
+)
+
+// RunImport imports an export file from the command line:
+//
+//	todo-service import -format todoist_csv [-name Inbox] [-dry-run] FILE
+func RunImport(cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	format := flags.String("format", "", strings.Join([]string{
+		constant.IMPORT_FORMAT_JSON,
+		constant.IMPORT_FORMAT_TODOIST_CSV,
+		constant.IMPORT_FORMAT_MICROSOFT_TODO_JSON,
+		constant.IMPORT_FORMAT_GOOGLE_TASKS_JSON,
+		constant.IMPORT_FORMAT_ICAL,
+	}, ", "))
+	name := flags.String("name", "", "title of the list when the file has none (default: file name)")
+	dryRun := flags.Bool("dry-run", false, "report what would be created without writing anything")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	if flags.NArg() != 1 {
+		return fmt.Errorf("usage: import -format FORMAT [-name NAME] [-dry-run] FILE")
+	}
+
+	path := flags.Arg(0)
+	if *name == "" {
+		*name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	pg, err := postgres.New(postgresURL(cfg))
+	if err != nil {
+		return err
+	}
+	defer pg.Close()
+
+	// A dry run never touches the database.
+	if !*dryRun {
+		err = RunMigrate(pg.Db)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Imports run by an operator are not held to the user quotas.
+	transferService := usecase.NewTransfer(repository.NewActivity(pg), repository.NewTask(pg), repository.NewText(pg), pg, entity.Quota{})
+
+	res, err := transferService.Import(context.Background(), entity.ImportRequest{
+		Format: *format,
+		Name:   *name,
+		DryRun: *dryRun,
+	}, file)
+	if err != nil {
+		return err
+	}
+
+	if res.DryRun {
+		fmt.Println("Dry run, nothing was written.")
+	}
+
+	for _, activity := range res.Activities {
+		fmt.Printf("%-20s %q: %d tasks (%d completed), %d texts %s\n", activity.Type, activity.Title, activity.Tasks, activity.CompletedTasks, activity.Texts, activity.ID)
+	}
+
+	fmt.Printf("Total: %d activities, %d tasks, %d texts\n", len(res.Activities), res.Tasks, res.Texts)
+
+	return nil
+}
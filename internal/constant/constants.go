@@ -1,5 +1,7 @@
 package constant
 
+import "time"
+
 const (
 	INVITATION_OPEN_QUEUE       string = "invitation_open"
 	INVITATION_COMING_QUEUE     string = "invitation_coming"
@@ -7,3 +9,117 @@ const (
 	INVITATION_SEND_GIFT_QUEUE  string = "invitation_send_gift"
 	INVITATION_CHECK_IN_QUEUE   string = "invitation_check_in"
 )
+
+const (
+	ENTITY_ACTIVITY string = "activity"
+	ENTITY_TASK     string = "task"
+	ENTITY_TEXT     string = "text"
+)
+
+const (
+	SYNC_OPERATION_CREATE string = "create"
+	SYNC_OPERATION_UPDATE string = "update"
+	SYNC_OPERATION_DELETE string = "delete"
+)
+
+const (
+	SYNC_STATUS_APPLIED   string = "applied"
+	SYNC_STATUS_CONFLICT  string = "conflict"
+	SYNC_STATUS_NOT_FOUND string = "not_found"
+	SYNC_STATUS_INVALID   string = "invalid"
+)
+
+const MAX_BULK_ITEMS int = 500
+
+// MAX_TITLE_LENGTH is the size of the title columns of activities and tasks.
+const MAX_TITLE_LENGTH int = 50
+
+const (
+	BULK_STATUS_SUCCESS           string = "success"
+	BULK_STATUS_NOT_FOUND         string = "not_found"
+	BULK_STATUS_PERMISSION_DENIED string = "permission_denied"
+	BULK_STATUS_INVALID           string = "invalid"
+)
+
+const (
+	ACTIVITY_TYPE_TASK      string = "activity_task"
+	ACTIVITY_TYPE_TEXT      string = "activity_text"
+	ACTIVITY_TYPE_CHECKLIST string = "activity_checklist"
+	ACTIVITY_TYPE_KANBAN    string = "activity_kanban"
+	ACTIVITY_TYPE_JOURNAL   string = "activity_journal"
+)
+
+const (
+	TEXT_FORMAT_HTML     string = "html"
+	TEXT_FORMAT_MARKDOWN string = "markdown"
+)
+
+const (
+	TEXT_DIFF_EQUAL  string = "equal"
+	TEXT_DIFF_INSERT string = "insert"
+	TEXT_DIFF_DELETE string = "delete"
+)
+
+const (
+	TEXT_CHECKPOINT_INTERVAL time.Duration = 5 * time.Second
+	TEXT_EDITOR_BUFFER       int           = 256
+)
+
+const (
+	MAX_ATTACHMENT_SIZE   int64 = 10 << 20
+	ATTACHMENT_CHUNK_SIZE int   = 64 << 10
+)
+
+const (
+	FEED_KIND_EVENT   string = "event"
+	FEED_KIND_COMMENT string = "comment"
+)
+
+const (
+	EVENT_ACTION_CREATED   string = "created"
+	EVENT_ACTION_COMPLETED string = "completed"
+	EVENT_ACTION_REORDERED string = "reordered"
+	EVENT_ACTION_COMMENTED string = "commented"
+)
+
+const (
+	DEFAULT_CURSOR_LIMIT int32 = 20
+	MAX_CURSOR_LIMIT     int32 = 100
+	MAX_COMMENT_LENGTH   int   = 5000
+)
+
+const (
+	IMPORT_FORMAT_JSON                string = "json"
+	IMPORT_FORMAT_TODOIST_CSV         string = "todoist_csv"
+	IMPORT_FORMAT_MICROSOFT_TODO_JSON string = "microsoft_todo_json"
+	IMPORT_FORMAT_GOOGLE_TASKS_JSON   string = "google_tasks_json"
+	IMPORT_FORMAT_ICAL                string = "ical"
+)
+
+const (
+	MAX_IMPORT_SIZE     int64  = 10 << 20
+	DEFAULT_IMPORT_NAME string = "Imported tasks"
+)
+
+const (
+	EXPORT_FORMAT_JSON     string = "json"
+	EXPORT_FORMAT_CSV      string = "csv"
+	EXPORT_FORMAT_MARKDOWN string = "markdown"
+	EXPORT_FORMAT_ICAL     string = "ical"
+)
+
+const (
+	EXPORT_PAGE_SIZE  int32 = 100
+	EXPORT_CHUNK_SIZE int   = 64 << 10
+)
+
+const (
+	FEED_TOKEN_BYTES           int    = 32
+	DEFAULT_FEED_TOKEN_NAME    string = "Calendar"
+	MAX_FEED_TOKEN_NAME_LENGTH int    = 255
+)
+
+const (
+	FEED_PATH   string = "/feeds"
+	CALDAV_PATH string = "/caldav"
+)
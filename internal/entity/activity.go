@@ -4,28 +4,39 @@ import "time"
 
 type (
 	Activity struct {
-		ID        string
-		Title     string
-		Type      string
-		CreatedAt time.Time
-		UpdatedAt time.Time
-		DeletedAt *time.Time
+		ID         string
+		Title      string
+		Type       string
+		Kind       int32
+		UserID     *string
+		CreatedAt  time.Time
+		UpdatedAt  time.Time
+		DeletedAt  *time.Time
+		ArchivedAt *time.Time
 	}
 
 	CreateActivityRequest struct {
-		Title string
-		Type  string
+		Title        string
+		Type         string
+		Kind         int32
+		UserID       *string
+		DefaultTasks []string
+		DefaultTexts []CreateTextRequest
 	}
 
 	UpdateActivityRequest struct {
 		ID    string
 		Title string `db:"title"`
 		Type  string `db:"type"`
+		Kind  int32
 	}
 
 	GetAllActivityRequest struct {
-		Search *string
-		Page   *int32
-		Limit  *int32
+		// UserID limits the list to the activities of one owner.
+		UserID          *string
+		Search          *string
+		Page            *int32
+		Limit           *int32
+		IncludeArchived *bool
 	}
 )
@@ -0,0 +1,16 @@
+package entity
+
+type (
+	// ActivityType describes how activities of one type behave: which children
+	// they may hold and which children they start with. Titles are validated
+	// the same way for every type.
+	ActivityType struct {
+		Name         string
+		Kind         int32
+		Description  string
+		AllowTasks   bool
+		AllowTexts   bool
+		DefaultTasks []string
+		DefaultTexts []string
+	}
+)
@@ -0,0 +1,35 @@
+package entity
+
+import "time"
+
+type (
+	Attachment struct {
+		ID          string
+		EntityType  string
+		EntityID    string
+		FileName    string
+		ContentType string
+		Size        int64
+		Checksum    string
+		StorageKey  string
+		CreatedAt   time.Time
+		UpdatedAt   time.Time
+		DeletedAt   *time.Time
+	}
+
+	// CreateAttachmentRequest describes an upload. Size and Checksum are what
+	// the client claims; the upload is rejected when the received file does not
+	// match them.
+	CreateAttachmentRequest struct {
+		EntityType string
+		EntityID   string
+		FileName   string
+		Size       *int64
+		Checksum   *string
+	}
+
+	GetAllAttachmentRequest struct {
+		EntityType string
+		EntityID   string
+	}
+)
@@ -4,4 +4,6 @@ type Paging struct {
 	CurrentPage int32
 	TotalPage   int32
 	Count       int32
+	// NextCursor is set by cursor paginated lists while more rows remain.
+	NextCursor string
 }
@@ -0,0 +1,53 @@
+package entity
+
+import "time"
+
+type (
+	FeedToken struct {
+		ID     string
+		UserID string
+		Name   string
+		// ActivityID limits the token to one activity; all when nil.
+		ActivityID *string
+		TokenHash  string
+		CreatedAt  time.Time
+		LastUsedAt *time.Time
+		RevokedAt  *time.Time
+	}
+
+	CreateFeedTokenRequest struct {
+		UserID     string
+		Name       string
+		ActivityID *string
+	}
+
+	// CreatedFeedToken carries the secret token, which is only shown once.
+	CreatedFeedToken struct {
+		FeedToken
+		Token     string
+		FeedURL   string
+		CalDAVURL string
+	}
+
+	GetAllDueTaskRequest struct {
+		// UserID limits the tasks to activities owned by that user.
+		UserID     string
+		ActivityID *string
+		Page       *int32
+		Limit      *int32
+	}
+
+	// CalendarTask is a task as written by a CalDAV client.
+	CalendarTask struct {
+		ActivityID string
+		Name       string
+		UID        string
+		Title      string
+		Completed  bool
+		Priority   int
+		DueAt      *time.Time
+		// IfMatch and IfNoneMatch hold the conditional headers of the request.
+		IfMatch     string
+		IfNoneMatch string
+	}
+)
@@ -0,0 +1,62 @@
+package entity
+
+import "time"
+
+type (
+	Comment struct {
+		ID        string
+		TaskID    string
+		AuthorID  string
+		Body      string
+		CreatedAt time.Time
+		UpdatedAt time.Time
+		EditedAt  *time.Time
+		DeletedAt *time.Time
+	}
+
+	CreateCommentRequest struct {
+		TaskID   string
+		AuthorID string
+		Body     string
+	}
+
+	UpdateCommentRequest struct {
+		ID   string
+		Body string
+	}
+
+	GetAllCommentRequest struct {
+		TaskID string
+		Cursor *string
+		Limit  *int32
+		After  *KeysetCursor
+	}
+
+	// FeedItem is either a task lifecycle event or a comment on a task of the
+	// activity.
+	FeedItem struct {
+		Kind      string
+		ID        string
+		TaskID    string
+		TaskTitle string
+		Action    string
+		AuthorID  string
+		Body      string
+		CreatedAt time.Time
+	}
+
+	GetFeedRequest struct {
+		ActivityID string
+		Cursor     *string
+		Limit      *int32
+		Before     *KeysetCursor
+	}
+
+	// KeysetCursor is the position after which a cursor paginated list
+	// continues.
+	KeysetCursor struct {
+		CreatedAt time.Time
+		Kind      string
+		ID        string
+	}
+)
@@ -0,0 +1,26 @@
+package entity
+
+import "errors"
+
+var (
+	ErrInvalidActivity     = errors.New("invalid activity")
+	ErrInvalidActivityType = errors.New("invalid activity type")
+	ErrChildNotAllowed     = errors.New("activity type does not allow this child")
+	ErrInvalidTextFormat   = errors.New("invalid text format")
+	ErrInvalidTextEdit     = errors.New("invalid text edit")
+	ErrTextSessionClosed   = errors.New("text editing session closed")
+	ErrTextSessionOpen     = errors.New("text is being edited in a session")
+	ErrInvalidAttachment   = errors.New("invalid attachment")
+	ErrAttachmentTooLarge  = errors.New("attachment too large")
+	ErrChecksumMismatch    = errors.New("attachment checksum mismatch")
+	ErrInvalidComment      = errors.New("invalid comment")
+	ErrInvalidCursor       = errors.New("invalid cursor")
+	ErrInvalidImport       = errors.New("invalid import")
+	ErrImportTooLarge      = errors.New("import too large")
+	ErrInvalidExport       = errors.New("invalid export")
+	ErrInvalidFeedToken    = errors.New("invalid feed token")
+	ErrInvalidCalendarTask = errors.New("invalid calendar task")
+	ErrPreconditionFailed  = errors.New("precondition failed")
+	ErrQuotaExceeded       = errors.New("quota exceeded")
+	ErrForbidden           = errors.New("not allowed for the caller")
+)
@@ -0,0 +1,33 @@
+package entity
+
+import "time"
+
+type (
+	Event struct {
+		ID         int64
+		XactID     int64
+		ActivityID string
+		EntityType string
+		EntityID   string
+		Action     string
+		CreatedAt  time.Time
+	}
+
+	// EventCursor orders events by the transaction that recorded them and
+	// then by id, which is the order in which they become final.
+	EventCursor struct {
+		XactID int64
+		ID     int64
+	}
+
+	GetAllEventRequest struct {
+		ActivityID string
+		Cursor     EventCursor
+		Limit      int32
+	}
+
+	WatchActivityRequest struct {
+		ActivityID string
+		Cursor     *EventCursor
+	}
+)
@@ -0,0 +1,8 @@
+package entity
+
+// Quota limits what a user can store; 0 means no limit.
+type Quota struct {
+	MaxActivities       int
+	MaxTasksPerActivity int
+	MaxNoteSize         int
+}
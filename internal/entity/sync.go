@@ -0,0 +1,67 @@
+package entity
+
+import "time"
+
+type (
+	SyncMutation struct {
+		ClientMutationID string
+		EntityType       string
+		Operation        string
+		EntityID         string
+		BaseUpdatedAt    *time.Time
+		ActivityID       string
+		Title            *string
+		Type             *string
+		IsActive         *bool
+		Priority         *int
+		Order            *int
+		Text             *string
+		PlainText        *string
+		UserID           *string
+	}
+
+	SyncMutationResult struct {
+		ClientMutationID string
+		EntityID         string
+		Status           string
+		Message          string
+	}
+
+	SyncRequest struct {
+		SinceToken     *int64
+		Mutations      []SyncMutation
+		SnapshotCursor *string
+		Limit          *int32
+	}
+
+	SyncResponse struct {
+		NextToken          int64
+		NextSnapshotCursor string
+		Activities         []Activity
+		Tasks              []Task
+		Texts              []Text
+		Results            []SyncMutationResult
+	}
+
+	GetChangesRequest struct {
+		UserID *string
+		Since  *int64
+		Until  int64
+		Limit  *int32
+		After  *SnapshotCursor
+	}
+
+	Changes struct {
+		Activities []Activity
+		Tasks      []Task
+		Texts      []Text
+		NextCursor string
+	}
+
+	// SnapshotCursor is the position of a paged snapshot: the token it was
+	// taken at and the last activity sent.
+	SnapshotCursor struct {
+		Token      int64
+		ActivityID string
+	}
+)
@@ -13,6 +13,10 @@ type (
 		CreatedAt  time.Time
 		UpdatedAt  time.Time
 		DeletedAt  *time.Time
+		ArchivedAt *time.Time
+		DueAt      *time.Time
+		ICalUID    *string
+		CalDAVName *string
 	}
 
 	CreateTaskRequest struct {
@@ -20,14 +24,21 @@ type (
 		ActivityID string
 		IsActive   *bool
 		Priority   int
+		DueAt      *time.Time
+		ICalUID    *string
+		CalDAVName *string
 	}
 
 	UpdateTaskRequest struct {
 		ID       string
-		Title    *string `db:"title"`
-		IsActive *bool   `db:"is_active"`
-		Priority *int    `db:"priority"`
-		Order    *int    `db:"order_position"`
+		Title    *string    `db:"title"`
+		IsActive *bool      `db:"is_active"`
+		Priority *int       `db:"priority"`
+		Order    *int       `db:"order_position"`
+		DueAt    *time.Time `db:"due_at"`
+		ICalUID  *string    `db:"ical_uid"`
+		// ClearDueAt removes the due date, which DueAt cannot express.
+		ClearDueAt bool
 	}
 
 	GetAllTaskRequest struct {
@@ -38,8 +49,26 @@ type (
 		IsOldest     *bool
 		IsAscending  *bool
 		IsDescending *bool
+		IsArchived   *bool
 		Search       *string
 		Page         *int32
 		Limit        *int32
 	}
+
+	BulkTaskRequest struct {
+		ActivityID string
+		IDs        []string
+	}
+
+	BulkMoveTaskRequest struct {
+		ActivityID       string
+		IDs              []string
+		TargetActivityID string
+	}
+
+	BulkTaskResult struct {
+		Index  int
+		ID     string
+		Status string
+	}
 )
@@ -7,6 +7,7 @@ type (
 		ID         string
 		ActivityID string
 		Text       string
+		PlainText  string
 		CreatedAt  time.Time
 		UpdatedAt  time.Time
 		DeletedAt  *time.Time
@@ -15,11 +16,15 @@ type (
 	CreateTextRequest struct {
 		ActivityID string
 		Text       string
+		PlainText  string
+		Format     string
 	}
 
 	UpdateTextRequest struct {
-		ID   string
-		Text *string `db:"text"`
+		ID        string
+		Text      *string `db:"text"`
+		PlainText *string `db:"plain_text"`
+		Format    string
 	}
 
 	GetAllTextRequest struct {
@@ -33,3 +38,62 @@ type (
 		Limit        *int32
 	}
 )
+
+type (
+	TextRevision struct {
+		TextID    string
+		Revision  int
+		Text      string
+		PlainText string
+		CreatedAt time.Time
+	}
+
+	GetAllTextRevisionRequest struct {
+		TextID string
+		Page   *int32
+		Limit  *int32
+	}
+
+	GetTextRevisionRequest struct {
+		TextID   string
+		Revision int
+	}
+
+	DiffTextRevisionRequest struct {
+		TextID       string
+		FromRevision int
+		ToRevision   *int
+	}
+
+	TextDiff struct {
+		Operation string
+		Text      string
+	}
+)
+
+type (
+	// TextOperationComponent is one retain, insert or delete step of a
+	// collaborative edit. Lengths are counted in Unicode code points.
+	TextOperationComponent struct {
+		Retain int
+		Insert string
+		Delete int
+	}
+
+	// TextEdit is an operation against Revision of a shared note. Edits sent to
+	// editors carry the revision they produced; an Ack confirms the editor's
+	// own edit without repeating it.
+	TextEdit struct {
+		Revision  int
+		Operation []TextOperationComponent
+		Ack       bool
+	}
+
+	TextEditor struct {
+		ID       int64
+		TextID   string
+		Revision int
+		Text     string
+		Edits    <-chan TextEdit
+	}
+)
@@ -0,0 +1,39 @@
+package entity
+
+type (
+	ImportRequest struct {
+		Format string
+		// Name titles the imported list when the file does not carry one.
+		Name   string
+		DryRun bool
+	}
+
+	ImportedActivity struct {
+		// ID is empty on a dry run.
+		ID             string
+		Title          string
+		Type           string
+		Tasks          int
+		CompletedTasks int
+		Texts          int
+	}
+
+	ImportResult struct {
+		DryRun     bool
+		Activities []ImportedActivity
+		Tasks      int
+		Texts      int
+	}
+
+	ExportRequest struct {
+		Format string
+		// ActivityID limits the export to one activity; all when empty.
+		ActivityID      string
+		IncludeArchived bool
+	}
+
+	ExportFile struct {
+		Name        string
+		ContentType string
+	}
+)
@@ -2,6 +2,9 @@ package handler
 
 import (
 	"context"
+	"errors"
+	"strconv"
+	"strings"
 
 	"github.com/digisata/todo-service/internal/entity"
 	activityPB "github.com/digisata/todo-service/stubs/activity"
@@ -27,6 +30,7 @@ func (h *ActivityHandler) Create(ctx context.Context, req *activityPB.CreateActi
 	payload := entity.CreateActivityRequest{
 		Title: req.GetTitle(),
 		Type:  req.GetType(),
+		Kind:  int32(req.GetKind()),
 	}
 
 	// if payload.Type == "text" {
@@ -36,6 +40,14 @@ func (h *ActivityHandler) Create(ctx context.Context, req *activityPB.CreateActi
 	// }
 
 	err := h.activityUseCase.CreateActivity(ctx, payload)
+	if errors.Is(err, entity.ErrInvalidActivityType) || errors.Is(err, entity.ErrInvalidActivity) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if errors.Is(err, entity.ErrQuotaExceeded) {
+		return nil, status.Error(codes.ResourceExhausted, err.Error())
+	}
+
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Internal Server error: %v", err)
 	}
@@ -52,13 +64,22 @@ func (g *ActivityHandler) Update(ctx context.Context, req *activityPB.UpdateActi
 		ID:    req.GetId(),
 		Title: req.GetTitle(),
 		Type:  req.GetType(),
+		Kind:  int32(req.GetKind()),
 	}
 
 	err := g.activityUseCase.UpdateActivity(ctx, payload)
+	if errors.Is(err, entity.ErrInvalidActivityType) || errors.Is(err, entity.ErrInvalidActivity) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
 	if err != nil && err.Error() == "data not found" {
 		return nil, status.Errorf(codes.NotFound, "data for userId: %v", req.GetId())
 	}
 
+	if errors.Is(err, entity.ErrChildNotAllowed) {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Internal Server error: %v", err)
 	}
@@ -81,11 +102,13 @@ func (h *ActivityHandler) Get(ctx context.Context, req *activityPB.GetActivityBy
 	}
 
 	getActivityByIDResponse := &activityPB.GetActivityByIDResponse{
-		Id:        data.ID,
-		Title:     data.Title,
-		Type:      data.Type,
-		CreatedAt: timestamppb.New(data.CreatedAt),
-		UpdatedAt: timestamppb.New(data.UpdatedAt),
+		Id:         data.ID,
+		Title:      data.Title,
+		Type:       data.Type,
+		Kind:       activityPB.ActivityType(data.Kind),
+		CreatedAt:  timestamppb.New(data.CreatedAt),
+		UpdatedAt:  timestamppb.New(data.UpdatedAt),
+		ArchivedAt: toTimestamp(data.ArchivedAt),
 	}
 
 	anyData, err := anypb.New(getActivityByIDResponse)
@@ -103,9 +126,10 @@ func (h *ActivityHandler) Get(ctx context.Context, req *activityPB.GetActivityBy
 
 func (g *ActivityHandler) GetAll(ctx context.Context, req *activityPB.GetAllActivityRequest) (*activityPB.GetAllActivityResponse, error) {
 	payload := entity.GetAllActivityRequest{
-		Search: req.Search,
-		Page:   req.Page,
-		Limit:  req.Limit,
+		Search:          req.Search,
+		Page:            req.Page,
+		Limit:           req.Limit,
+		IncludeArchived: req.IncludeArchived,
 	}
 
 	data, paging, err := g.activityUseCase.GetAllActivity(ctx, payload)
@@ -125,11 +149,13 @@ func (g *ActivityHandler) GetAll(ctx context.Context, req *activityPB.GetAllActi
 
 	for _, activity := range data {
 		data := &activityPB.GetActivityByIDResponse{
-			Id:        activity.ID,
-			Title:     activity.Title,
-			Type:      activity.Type,
-			CreatedAt: timestamppb.New(activity.CreatedAt),
-			UpdatedAt: timestamppb.New(activity.UpdatedAt),
+			Id:         activity.ID,
+			Title:      activity.Title,
+			Type:       activity.Type,
+			Kind:       activityPB.ActivityType(activity.Kind),
+			CreatedAt:  timestamppb.New(activity.CreatedAt),
+			UpdatedAt:  timestamppb.New(activity.UpdatedAt),
+			ArchivedAt: toTimestamp(activity.ArchivedAt),
 		}
 
 		res.Data = append(res.Data, data)
@@ -154,3 +180,124 @@ func (g *ActivityHandler) Delete(ctx context.Context, req *activityPB.DeleteActi
 
 	return res, nil
 }
+
+func (g *ActivityHandler) GetAllTypes(ctx context.Context, req *activityPB.GetAllActivityTypeRequest) (*activityPB.GetAllActivityTypeResponse, error) {
+	data := g.activityUseCase.GetAllActivityType(ctx)
+
+	res := &activityPB.GetAllActivityTypeResponse{
+		Message: "Success",
+		Data:    []*activityPB.ActivityTypeDefinition{},
+	}
+
+	for _, activityType := range data {
+		res.Data = append(res.Data, &activityPB.ActivityTypeDefinition{
+			Name:        activityType.Name,
+			Kind:        activityPB.ActivityType(activityType.Kind),
+			Description: activityType.Description,
+			AllowTasks:  activityType.AllowTasks,
+			AllowTexts:  activityType.AllowTexts,
+		})
+	}
+
+	return res, nil
+}
+
+func (g *ActivityHandler) Archive(ctx context.Context, req *activityPB.ArchiveActivityByIDRequest) (*activityPB.ActivityBaseResponse, error) {
+	err := g.activityUseCase.ArchiveActivity(ctx, req.GetId())
+	if err != nil && err.Error() == "data not found" {
+		return nil, status.Errorf(codes.NotFound, "data for userId: %v", req.GetId())
+	}
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Internal Server error: %v", err)
+	}
+
+	res := &activityPB.ActivityBaseResponse{
+		Message: "Success",
+	}
+
+	return res, nil
+}
+
+func (g *ActivityHandler) Unarchive(ctx context.Context, req *activityPB.UnarchiveActivityByIDRequest) (*activityPB.ActivityBaseResponse, error) {
+	err := g.activityUseCase.UnarchiveActivity(ctx, req.GetId())
+	if err != nil && err.Error() == "data not found" {
+		return nil, status.Errorf(codes.NotFound, "data for userId: %v", req.GetId())
+	}
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Internal Server error: %v", err)
+	}
+
+	res := &activityPB.ActivityBaseResponse{
+		Message: "Success",
+	}
+
+	return res, nil
+}
+
+func (g *ActivityHandler) Watch(req *activityPB.WatchActivityRequest, stream activityPB.ActivityService_WatchServer) error {
+	payload := entity.WatchActivityRequest{
+		ActivityID: req.GetActivityId(),
+	}
+
+	if req.Cursor != nil {
+		cursor, err := parseEventCursor(req.GetCursor())
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid cursor: %v", req.GetCursor())
+		}
+
+		payload.Cursor = &cursor
+	}
+
+	err := g.activityUseCase.WatchActivity(stream.Context(), payload, func(event entity.Event) error {
+		return stream.Send(&activityPB.ActivityEvent{
+			Cursor:     formatEventCursor(event),
+			ActivityId: event.ActivityID,
+			EntityType: event.EntityType,
+			EntityId:   event.EntityID,
+			Action:     event.Action,
+			CreatedAt:  timestamppb.New(event.CreatedAt),
+		})
+	})
+	if err != nil && err.Error() == "sql: no rows in result set" {
+		return status.Errorf(codes.NotFound, "data for activityId: %v", req.GetActivityId())
+	}
+
+	if err != nil && stream.Context().Err() != nil {
+		return status.FromContextError(stream.Context().Err()).Err()
+	}
+
+	if err != nil {
+		return status.Errorf(codes.Internal, "Internal Server error: %v", err)
+	}
+
+	return nil
+}
+
+// Event cursors are "<transaction>.<id>".
+func formatEventCursor(event entity.Event) string {
+	return strconv.FormatInt(event.XactID, 10) + "." + strconv.FormatInt(event.ID, 10)
+}
+
+func parseEventCursor(s string) (entity.EventCursor, error) {
+	var cursor entity.EventCursor
+
+	xactID, id, ok := strings.Cut(s, ".")
+	if !ok {
+		return cursor, errors.New("cursor must be <transaction>.<id>")
+	}
+
+	var err error
+	cursor.XactID, err = strconv.ParseInt(xactID, 10, 64)
+	if err != nil {
+		return cursor, err
+	}
+
+	cursor.ID, err = strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return cursor, err
+	}
+
+	return cursor, nil
+}
@@ -0,0 +1,207 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/digisata/todo-service/internal/constant"
+	"github.com/digisata/todo-service/internal/entity"
+	attachmentPB "github.com/digisata/todo-service/stubs/attachment"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type AttachmentHandler struct {
+	attachmentPB.UnimplementedAttachmentServiceServer
+	attachmentUseCase AttachmentUseCase
+}
+
+func NewAttachment(attachmentUseCase AttachmentUseCase) *AttachmentHandler {
+	return &AttachmentHandler{
+		attachmentUseCase: attachmentUseCase,
+	}
+}
+
+func (h *AttachmentHandler) UploadAttachment(stream attachmentPB.AttachmentService_UploadAttachmentServer) error {
+	ctx := stream.Context()
+
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	info := req.GetInfo()
+	if info == nil {
+		return status.Error(codes.InvalidArgument, "first message must be info")
+	}
+
+	payload := entity.CreateAttachmentRequest{
+		EntityType: info.GetEntityType(),
+		EntityID:   info.GetEntityId(),
+		FileName:   info.GetFileName(),
+		Size:       info.Size,
+		Checksum:   info.Checksum,
+	}
+
+	// Chunks are piped into the usecase as they arrive so the file is never
+	// held in memory as a whole.
+	pr, pw := io.Pipe()
+	go func() {
+		for {
+			req, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				pw.Close()
+				return
+			}
+
+			if err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+
+			if req.GetInfo() != nil {
+				pw.CloseWithError(status.Error(codes.InvalidArgument, "expected chunk"))
+				return
+			}
+
+			_, err = pw.Write(req.GetChunk())
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	data, err := h.attachmentUseCase.UploadAttachment(ctx, payload, pr)
+	pr.Close()
+
+	if errors.Is(err, entity.ErrAttachmentTooLarge) {
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+
+	if errors.Is(err, entity.ErrInvalidAttachment) || errors.Is(err, entity.ErrChecksumMismatch) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err != nil && err.Error() == "sql: no rows in result set" {
+		return status.Errorf(codes.NotFound, "data for %v: %v", info.GetEntityType(), info.GetEntityId())
+	}
+
+	if _, ok := status.FromError(err); ok && err != nil {
+		return err
+	}
+
+	if err != nil {
+		return status.Errorf(codes.Internal, "Internal Server error: %v", err)
+	}
+
+	res := &attachmentPB.UploadAttachmentResponse{
+		Message:    "Success",
+		Attachment: newAttachmentResponse(data),
+	}
+
+	return stream.SendAndClose(res)
+}
+
+func (h *AttachmentHandler) DownloadAttachment(req *attachmentPB.DownloadAttachmentRequest, stream attachmentPB.AttachmentService_DownloadAttachmentServer) error {
+	data, blob, err := h.attachmentUseCase.DownloadAttachment(stream.Context(), req.GetId())
+	if err != nil && (err.Error() == "sql: no rows in result set" || err.Error() == "data not found") {
+		return status.Errorf(codes.NotFound, "data for attachmentId: %v", req.GetId())
+	}
+
+	if err != nil {
+		return status.Errorf(codes.Internal, "Internal Server error: %v", err)
+	}
+	defer blob.Close()
+
+	err = stream.Send(&attachmentPB.DownloadAttachmentResponse{
+		Payload: &attachmentPB.DownloadAttachmentResponse_Attachment{
+			Attachment: newAttachmentResponse(data),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	buf := make([]byte, constant.ATTACHMENT_CHUNK_SIZE)
+	for {
+		n, err := io.ReadFull(blob, buf)
+		if n > 0 {
+			sendErr := stream.Send(&attachmentPB.DownloadAttachmentResponse{
+				Payload: &attachmentPB.DownloadAttachmentResponse_Chunk{
+					Chunk: buf[:n],
+				},
+			})
+			if sendErr != nil {
+				return sendErr
+			}
+		}
+
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil
+		}
+
+		if errors.Is(err, entity.ErrChecksumMismatch) {
+			return status.Error(codes.DataLoss, err.Error())
+		}
+
+		if err != nil {
+			return status.Errorf(codes.Internal, "Internal Server error: %v", err)
+		}
+	}
+}
+
+func (h *AttachmentHandler) ListAttachments(ctx context.Context, req *attachmentPB.ListAttachmentsRequest) (*attachmentPB.ListAttachmentsResponse, error) {
+	payload := entity.GetAllAttachmentRequest{
+		EntityType: req.GetEntityType(),
+		EntityID:   req.GetEntityId(),
+	}
+
+	data, err := h.attachmentUseCase.GetAllAttachment(ctx, payload)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Internal Server error: %v", err)
+	}
+
+	res := &attachmentPB.ListAttachmentsResponse{
+		Message:     "Success",
+		Attachments: []*attachmentPB.GetAttachmentByIDResponse{},
+	}
+	for _, attachment := range data {
+		res.Attachments = append(res.Attachments, newAttachmentResponse(attachment))
+	}
+
+	return res, nil
+}
+
+func (h *AttachmentHandler) DeleteAttachment(ctx context.Context, req *attachmentPB.DeleteAttachmentByIDRequest) (*attachmentPB.AttachmentBaseResponse, error) {
+	err := h.attachmentUseCase.DeleteAttachment(ctx, req.GetId())
+	if err != nil && (err.Error() == "sql: no rows in result set" || err.Error() == "data not found") {
+		return nil, status.Errorf(codes.NotFound, "data for attachmentId: %v", req.GetId())
+	}
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Internal Server error: %v", err)
+	}
+
+	res := &attachmentPB.AttachmentBaseResponse{
+		Message: "Success",
+	}
+
+	return res, nil
+}
+
+func newAttachmentResponse(attachment entity.Attachment) *attachmentPB.GetAttachmentByIDResponse {
+	return &attachmentPB.GetAttachmentByIDResponse{
+		Id:          attachment.ID,
+		EntityType:  attachment.EntityType,
+		EntityId:    attachment.EntityID,
+		FileName:    attachment.FileName,
+		ContentType: attachment.ContentType,
+		Size:        attachment.Size,
+		Checksum:    attachment.Checksum,
+		CreatedAt:   timestamppb.New(attachment.CreatedAt),
+		UpdatedAt:   timestamppb.New(attachment.UpdatedAt),
+	}
+}
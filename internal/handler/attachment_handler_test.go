@@ -0,0 +1,310 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/digisata/todo-service/internal/constant"
+	"github.com/digisata/todo-service/internal/entity"
+	"github.com/digisata/todo-service/internal/usecase"
+	"github.com/digisata/todo-service/pkg/blobstore"
+	"github.com/digisata/todo-service/pkg/blobstore/blobstoretest"
+	attachmentPB "github.com/digisata/todo-service/stubs/attachment"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const testMaxAttachmentSize = 64 << 10
+
+type memAttachmentRepository struct {
+	mu   sync.Mutex
+	rows map[string]entity.Attachment
+}
+
+func (r *memAttachmentRepository) Create(ctx context.Context, req entity.Attachment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.rows[req.ID] = req
+
+	return nil
+}
+
+func (r *memAttachmentRepository) GetAll(ctx context.Context, req entity.GetAllAttachmentRequest) ([]entity.Attachment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var res []entity.Attachment
+	for _, row := range r.rows {
+		if row.EntityType == req.EntityType && row.EntityID == req.EntityID {
+			res = append(res, row)
+		}
+	}
+
+	return res, nil
+}
+
+func (r *memAttachmentRepository) GetByID(ctx context.Context, id string) (entity.Attachment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	row, ok := r.rows[id]
+	if !ok {
+		return row, errors.New("sql: no rows in result set")
+	}
+
+	return row, nil
+}
+
+func (r *memAttachmentRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.rows, id)
+
+	return nil
+}
+
+// anyTaskRepository reports every task as existing.
+type anyTaskRepository struct {
+	usecase.TaskRepository
+}
+
+func (anyTaskRepository) GetByID(ctx context.Context, id string) (entity.Task, error) {
+	return entity.Task{ID: id}, nil
+}
+
+type attachmentTest struct {
+	client attachmentPB.AttachmentServiceClient
+	store  blobstore.BlobStore
+	repo   *memAttachmentRepository
+}
+
+// newAttachmentTests serves the attachment service in memory once per blob
+// store driver.
+func newAttachmentTests(t *testing.T) map[string]attachmentTest {
+	t.Helper()
+
+	local, err := blobstore.NewLocal(blobstore.LocalConfig{Dir: t.TempDir()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv := blobstoretest.NewS3Server()
+	t.Cleanup(srv.Close)
+
+	s3, err := blobstore.NewS3(srv.Config("attachments"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res := make(map[string]attachmentTest)
+	for driver, store := range map[string]blobstore.BlobStore{
+		blobstore.DriverLocal: local,
+		blobstore.DriverS3:    s3,
+	} {
+		repo := &memAttachmentRepository{rows: make(map[string]entity.Attachment)}
+		attachmentUseCase := usecase.NewAttachment(repo, anyTaskRepository{}, nil, store, testMaxAttachmentSize)
+
+		lis := bufconn.Listen(1 << 20)
+		grpcServer := grpc.NewServer()
+		attachmentPB.RegisterAttachmentServiceServer(grpcServer, NewAttachment(attachmentUseCase))
+		go grpcServer.Serve(lis)
+		t.Cleanup(grpcServer.Stop)
+
+		conn, err := grpc.NewClient("passthrough:///bufnet",
+			grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+				return lis.DialContext(ctx)
+			}),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { conn.Close() })
+
+		res[driver] = attachmentTest{
+			client: attachmentPB.NewAttachmentServiceClient(conn),
+			store:  store,
+			repo:   repo,
+		}
+	}
+
+	return res
+}
+
+func upload(ctx context.Context, client attachmentPB.AttachmentServiceClient, info *attachmentPB.AttachmentInfo, data []byte) (*attachmentPB.UploadAttachmentResponse, error) {
+	stream, err := client.UploadAttachment(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = stream.Send(&attachmentPB.UploadAttachmentRequest{
+		Payload: &attachmentPB.UploadAttachmentRequest_Info{Info: info},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for len(data) > 0 {
+		n := min(len(data), 4<<10)
+		err = stream.Send(&attachmentPB.UploadAttachmentRequest{
+			Payload: &attachmentPB.UploadAttachmentRequest_Chunk{Chunk: data[:n]},
+		})
+		if errors.Is(err, io.EOF) {
+			// The server already answered, CloseAndRecv returns its status.
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+		data = data[n:]
+	}
+
+	return stream.CloseAndRecv()
+}
+
+func download(ctx context.Context, client attachmentPB.AttachmentServiceClient, id string) ([]byte, error) {
+	stream, err := client.DownloadAttachment(ctx, &attachmentPB.DownloadAttachmentRequest{Id: id})
+	if err != nil {
+		return nil, err
+	}
+
+	var data []byte
+	for {
+		res, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return data, nil
+		}
+
+		if err != nil {
+			return data, err
+		}
+		data = append(data, res.GetChunk()...)
+	}
+}
+
+func testFile(size int) ([]byte, string) {
+	data := bytes.Repeat([]byte("todo-service "), size/13+1)[:size]
+	sum := sha256.Sum256(data)
+
+	return data, hex.EncodeToString(sum[:])
+}
+
+func newInfo(size *int64, checksum *string) *attachmentPB.AttachmentInfo {
+	return &attachmentPB.AttachmentInfo{
+		EntityType: constant.ENTITY_TASK,
+		EntityId:   "task-1",
+		FileName:   "notes.txt",
+		Size:       size,
+		Checksum:   checksum,
+	}
+}
+
+func TestAttachmentRoundTrip(t *testing.T) {
+	for driver, tt := range newAttachmentTests(t) {
+		t.Run(driver, func(t *testing.T) {
+			ctx := context.Background()
+			data, checksum := testFile(testMaxAttachmentSize)
+			size := int64(len(data))
+
+			res, err := upload(ctx, tt.client, newInfo(&size, &checksum), data)
+			if err != nil {
+				t.Fatalf("upload: %v", err)
+			}
+
+			if res.GetAttachment().GetChecksum() != checksum || res.GetAttachment().GetSize() != size {
+				t.Errorf("stored %d bytes with sha256 %s", res.GetAttachment().GetSize(), res.GetAttachment().GetChecksum())
+			}
+
+			got, err := download(ctx, tt.client, res.GetAttachment().GetId())
+			if err != nil {
+				t.Fatalf("download: %v", err)
+			}
+
+			if !bytes.Equal(got, data) {
+				t.Errorf("downloaded %d bytes, want %d", len(got), len(data))
+			}
+		})
+	}
+}
+
+func TestAttachmentSizeLimit(t *testing.T) {
+	for driver, tt := range newAttachmentTests(t) {
+		t.Run(driver, func(t *testing.T) {
+			ctx := context.Background()
+			data, _ := testFile(testMaxAttachmentSize + 1)
+			announced := int64(len(data))
+
+			for name, size := range map[string]*int64{"announced": &announced, "streamed": nil} {
+				_, err := upload(ctx, tt.client, newInfo(size, nil), data)
+				if status.Code(err) != codes.ResourceExhausted {
+					t.Errorf("%s: got %v, want %v", name, err, codes.ResourceExhausted)
+				}
+			}
+
+			if len(tt.repo.rows) != 0 {
+				t.Errorf("%d attachments stored", len(tt.repo.rows))
+			}
+		})
+	}
+}
+
+func TestAttachmentUploadChecksumMismatch(t *testing.T) {
+	for driver, tt := range newAttachmentTests(t) {
+		t.Run(driver, func(t *testing.T) {
+			data, _ := testFile(1 << 10)
+			_, other := testFile(2 << 10)
+
+			_, err := upload(context.Background(), tt.client, newInfo(nil, &other), data)
+			if status.Code(err) != codes.InvalidArgument {
+				t.Errorf("got %v, want %v", err, codes.InvalidArgument)
+			}
+
+			if len(tt.repo.rows) != 0 {
+				t.Errorf("%d attachments stored", len(tt.repo.rows))
+			}
+		})
+	}
+}
+
+func TestAttachmentDownloadCorrupted(t *testing.T) {
+	for driver, tt := range newAttachmentTests(t) {
+		t.Run(driver, func(t *testing.T) {
+			ctx := context.Background()
+			data, _ := testFile(8 << 10)
+
+			res, err := upload(ctx, tt.client, newInfo(nil, nil), data)
+			if err != nil {
+				t.Fatalf("upload: %v", err)
+			}
+
+			// Flip one byte behind the service's back.
+			corrupted := bytes.Clone(data)
+			corrupted[len(corrupted)/2] ^= 0xff
+			key := tt.repo.rows[res.GetAttachment().GetId()].StorageKey
+
+			err = tt.store.Put(ctx, key, bytes.NewReader(corrupted), int64(len(corrupted)), "text/plain")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = download(ctx, tt.client, res.GetAttachment().GetId())
+			if status.Code(err) != codes.DataLoss {
+				t.Errorf("got %v, want %v", err, codes.DataLoss)
+			}
+		})
+	}
+}
@@ -0,0 +1,410 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/digisata/todo-service/internal/constant"
+	"github.com/digisata/todo-service/internal/entity"
+	"github.com/digisata/todo-service/internal/shared"
+	"github.com/digisata/todo-service/pkg/exporter"
+	"github.com/digisata/todo-service/pkg/importer"
+	"github.com/emersion/go-ical"
+	"github.com/emersion/go-webdav"
+	"github.com/emersion/go-webdav/caldav"
+)
+
+type feedTokenKey struct{}
+
+// CalDAVBackend serves every activity of the token's user holding tasks as a
+// calendar of VTODO components. Paths look like
+//
+//	/caldav/<token id>/                              principal
+//	/caldav/<token id>/calendars/                    calendar home
+//	/caldav/<token id>/calendars/<activity id>/      calendar
+//	/caldav/<token id>/calendars/<activity id>/<name> task
+//
+// The feed token is put in the context by CalendarHTTPHandler.
+type CalDAVBackend struct {
+	calendarUseCase CalendarUseCase
+}
+
+func NewCalDAVBackend(calendarUseCase CalendarUseCase) *CalDAVBackend {
+	return &CalDAVBackend{
+		calendarUseCase: calendarUseCase,
+	}
+}
+
+func (b *CalDAVBackend) CurrentUserPrincipal(ctx context.Context) (string, error) {
+	token, err := feedTokenFromContext(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return path.Join(constant.CALDAV_PATH, token.ID) + "/", nil
+}
+
+func (b *CalDAVBackend) CalendarHomeSetPath(ctx context.Context) (string, error) {
+	principal, err := b.CurrentUserPrincipal(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return principal + "calendars/", nil
+}
+
+func (b *CalDAVBackend) CreateCalendar(ctx context.Context, calendar *caldav.Calendar) error {
+	return webdav.NewHTTPError(http.StatusForbidden, errors.New("calendars are created as activities"))
+}
+
+func (b *CalDAVBackend) ListCalendars(ctx context.Context) ([]caldav.Calendar, error) {
+	var res []caldav.Calendar
+
+	token, err := feedTokenFromContext(ctx)
+	if err != nil {
+		return res, err
+	}
+
+	home, err := b.CalendarHomeSetPath(ctx)
+	if err != nil {
+		return res, err
+	}
+
+	data, err := b.calendarUseCase.GetAllCalendar(ctx, token)
+	if err != nil {
+		return res, calDAVError(err)
+	}
+
+	for _, activity := range data {
+		res = append(res, newCalendar(home, activity))
+	}
+
+	return res, nil
+}
+
+func (b *CalDAVBackend) GetCalendar(ctx context.Context, p string) (*caldav.Calendar, error) {
+	token, activityID, name, err := b.parsePath(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+
+	if name != "" {
+		return nil, webdav.NewHTTPError(http.StatusNotFound, fmt.Errorf("%s is not a calendar", p))
+	}
+
+	home, err := b.CalendarHomeSetPath(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := b.calendarUseCase.GetCalendar(ctx, token, activityID)
+	if err != nil {
+		return nil, calDAVError(err)
+	}
+
+	res := newCalendar(home, data)
+
+	return &res, nil
+}
+
+func (b *CalDAVBackend) GetCalendarObject(ctx context.Context, p string, req *caldav.CalendarCompRequest) (*caldav.CalendarObject, error) {
+	token, activityID, name, err := b.parsePath(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := b.calendarUseCase.GetCalendarTask(ctx, token, activityID, name)
+	if err != nil {
+		return nil, calDAVError(err)
+	}
+
+	res := newCalendarObject(path.Dir(p), data)
+
+	return &res, nil
+}
+
+func (b *CalDAVBackend) ListCalendarObjects(ctx context.Context, p string, req *caldav.CalendarCompRequest) ([]caldav.CalendarObject, error) {
+	var res []caldav.CalendarObject
+
+	token, activityID, name, err := b.parsePath(ctx, p)
+	if err != nil {
+		return res, err
+	}
+
+	if name != "" {
+		return res, webdav.NewHTTPError(http.StatusNotFound, fmt.Errorf("%s is not a calendar", p))
+	}
+
+	data, err := b.calendarUseCase.GetAllCalendarTask(ctx, token, activityID)
+	if err != nil {
+		return res, calDAVError(err)
+	}
+
+	for _, task := range data {
+		res = append(res, newCalendarObject(p, task))
+	}
+
+	return res, nil
+}
+
+func (b *CalDAVBackend) QueryCalendarObjects(ctx context.Context, p string, query *caldav.CalendarQuery) ([]caldav.CalendarObject, error) {
+	data, err := b.ListCalendarObjects(ctx, p, &query.CompRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return caldav.Filter(query, data)
+}
+
+func (b *CalDAVBackend) PutCalendarObject(ctx context.Context, p string, calendar *ical.Calendar, opts *caldav.PutCalendarObjectOptions) (*caldav.CalendarObject, error) {
+	token, activityID, name, err := b.parsePath(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+
+	if name == "" {
+		return nil, webdav.NewHTTPError(http.StatusMethodNotAllowed, fmt.Errorf("%s is a calendar", p))
+	}
+
+	payload, err := newCalendarTask(calendar)
+	if err != nil {
+		return nil, calDAVError(err)
+	}
+
+	payload.ActivityID = activityID
+	payload.Name = name
+
+	payload.IfMatch, err = conditionalETag(opts.IfMatch)
+	if err != nil {
+		return nil, webdav.NewHTTPError(http.StatusBadRequest, err)
+	}
+
+	payload.IfNoneMatch, err = conditionalETag(opts.IfNoneMatch)
+	if err != nil {
+		return nil, webdav.NewHTTPError(http.StatusBadRequest, err)
+	}
+
+	data, err := b.calendarUseCase.PutCalendarTask(ctx, token, payload)
+	if err != nil {
+		return nil, calDAVError(err)
+	}
+
+	res := newCalendarObject(path.Dir(p), data)
+
+	return &res, nil
+}
+
+func (b *CalDAVBackend) DeleteCalendarObject(ctx context.Context, p string) error {
+	token, activityID, name, err := b.parsePath(ctx, p)
+	if err != nil {
+		return err
+	}
+
+	if name == "" {
+		return webdav.NewHTTPError(http.StatusForbidden, errors.New("calendars are deleted as activities"))
+	}
+
+	err = b.calendarUseCase.DeleteCalendarTask(ctx, token, activityID, name)
+	if err != nil {
+		return calDAVError(err)
+	}
+
+	return nil
+}
+
+// parsePath splits a path below the calendar home into the activity ID and,
+// for tasks, the resource name.
+func (b *CalDAVBackend) parsePath(ctx context.Context, p string) (entity.FeedToken, string, string, error) {
+	token, err := feedTokenFromContext(ctx)
+	if err != nil {
+		return token, "", "", err
+	}
+
+	home, err := b.CalendarHomeSetPath(ctx)
+	if err != nil {
+		return token, "", "", err
+	}
+
+	rel, ok := strings.CutPrefix(path.Clean(p), home)
+	if !ok || rel == "" {
+		return token, "", "", webdav.NewHTTPError(http.StatusNotFound, fmt.Errorf("%s not found", p))
+	}
+
+	parts := strings.Split(rel, "/")
+	switch len(parts) {
+	case 1:
+		return token, parts[0], "", nil
+	case 2:
+		return token, parts[0], parts[1], nil
+	default:
+		return token, "", "", webdav.NewHTTPError(http.StatusNotFound, fmt.Errorf("%s not found", p))
+	}
+}
+
+func feedTokenFromContext(ctx context.Context) (entity.FeedToken, error) {
+	token, ok := ctx.Value(feedTokenKey{}).(entity.FeedToken)
+	if !ok {
+		return token, webdav.NewHTTPError(http.StatusUnauthorized, entity.ErrInvalidFeedToken)
+	}
+
+	return token, nil
+}
+
+func calDAVError(err error) error {
+	switch {
+	case err.Error() == "sql: no rows in result set" || err.Error() == "data not found":
+		return webdav.NewHTTPError(http.StatusNotFound, err)
+	case errors.Is(err, entity.ErrChildNotAllowed):
+		return webdav.NewHTTPError(http.StatusNotFound, err)
+	case errors.Is(err, entity.ErrInvalidCalendarTask):
+		return webdav.NewHTTPError(http.StatusBadRequest, err)
+	case errors.Is(err, entity.ErrPreconditionFailed):
+		return webdav.NewHTTPError(http.StatusPreconditionFailed, err)
+	case errors.Is(err, entity.ErrQuotaExceeded):
+		return webdav.NewHTTPError(http.StatusInsufficientStorage, err)
+	default:
+		return err
+	}
+}
+
+// conditionalETag unquotes an If-Match or If-None-Match header, keeping the
+// wildcard as it is.
+func conditionalETag(match webdav.ConditionalMatch) (string, error) {
+	if !match.IsSet() || match.IsWildcard() {
+		return string(match), nil
+	}
+
+	return match.ETag()
+}
+
+func newCalendar(home string, activity entity.Activity) caldav.Calendar {
+	return caldav.Calendar{
+		Path:                  home + activity.ID + "/",
+		Name:                  activity.Title,
+		SupportedComponentSet: []string{ical.CompToDo},
+	}
+}
+
+func newCalendarObject(calendarPath string, task entity.Task) caldav.CalendarObject {
+	name := shared.Deref(task.CalDAVName)
+	if name == "" {
+		name = task.ID + ".ics"
+	}
+
+	uid := shared.Deref(task.ICalUID)
+	if uid == "" {
+		uid = task.ID
+	}
+
+	todo := ical.NewComponent(ical.CompToDo)
+	todo.Props.SetText(ical.PropUID, uid)
+	todo.Props.SetDateTime(ical.PropDateTimeStamp, task.UpdatedAt.UTC())
+	todo.Props.SetDateTime(ical.PropCreated, task.CreatedAt.UTC())
+	todo.Props.SetDateTime(ical.PropLastModified, task.UpdatedAt.UTC())
+	todo.Props.SetText(ical.PropSummary, task.Title)
+
+	if task.DueAt != nil {
+		todo.Props.SetDateTime(ical.PropDue, task.DueAt.UTC())
+	}
+
+	if priority := exporter.ICalPriority(task.Priority); priority != 0 {
+		prop := ical.NewProp(ical.PropPriority)
+		prop.Value = strconv.Itoa(priority)
+		todo.Props.Set(prop)
+	}
+
+	if task.IsActive {
+		todo.Props.SetText(ical.PropStatus, "NEEDS-ACTION")
+	} else {
+		todo.Props.SetText(ical.PropStatus, "COMPLETED")
+		todo.Props.SetDateTime(ical.PropCompleted, task.UpdatedAt.UTC())
+	}
+
+	data := ical.NewCalendar()
+	data.Props.SetText(ical.PropVersion, "2.0")
+	data.Props.SetText(ical.PropProductID, exporter.ICalProductID)
+	data.Children = append(data.Children, todo)
+
+	return caldav.CalendarObject{
+		Path:    strings.TrimSuffix(calendarPath, "/") + "/" + name,
+		ModTime: task.UpdatedAt,
+		ETag:    shared.TaskETag(task),
+		Data:    data,
+	}
+}
+
+// newCalendarTask reads the single VTODO of a calendar object. Properties the
+// service has no place for, like alarms or descriptions, are dropped.
+func newCalendarTask(calendar *ical.Calendar) (entity.CalendarTask, error) {
+	var (
+		res  entity.CalendarTask
+		todo *ical.Component
+	)
+
+	for _, child := range calendar.Children {
+		switch child.Name {
+		case ical.CompTimezone:
+		case ical.CompToDo:
+			if todo != nil {
+				return res, fmt.Errorf("%w: more than one VTODO", entity.ErrInvalidCalendarTask)
+			}
+			todo = child
+		default:
+			return res, fmt.Errorf("%w: %s is not supported", entity.ErrInvalidCalendarTask, child.Name)
+		}
+	}
+
+	if todo == nil {
+		return res, fmt.Errorf("%w: no VTODO", entity.ErrInvalidCalendarTask)
+	}
+
+	res.UID, _ = todo.Props.Text(ical.PropUID)
+	res.Title, _ = todo.Props.Text(ical.PropSummary)
+
+	// STATUS wins over COMPLETED, which some clients leave behind when a
+	// task is reopened.
+	if prop := todo.Props.Get(ical.PropStatus); prop != nil {
+		res.Completed = strings.EqualFold(prop.Value, "COMPLETED")
+	} else {
+		res.Completed = todo.Props.Get(ical.PropCompleted) != nil
+	}
+
+	if prop := todo.Props.Get(ical.PropPriority); prop != nil {
+		res.Priority = importer.ICalPriority(prop.Value)
+	}
+
+	if prop := todo.Props.Get(ical.PropDue); prop != nil {
+		dueAt, err := icalDateTime(prop)
+		if err != nil {
+			return res, fmt.Errorf("%w: %v", entity.ErrInvalidCalendarTask, err)
+		}
+		res.DueAt = &dueAt
+	}
+
+	return res, nil
+}
+
+// icalDateTime reads a date or date-time in UTC. Zones Go does not know, like
+// the Windows names Outlook writes, are read as UTC.
+func icalDateTime(prop *ical.Prop) (time.Time, error) {
+	t, err := prop.DateTime(time.UTC)
+	if err != nil && prop.Params.Get(ical.PropTimezoneID) != "" {
+		floating := *prop
+		floating.Params = ical.Params{}
+		for name, values := range prop.Params {
+			if name != ical.PropTimezoneID {
+				floating.Params[name] = values
+			}
+		}
+
+		t, err = floating.DateTime(time.UTC)
+	}
+
+	return t.UTC(), err
+}
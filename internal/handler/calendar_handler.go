@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	"github.com/digisata/todo-service/internal/entity"
+	calendarPB "github.com/digisata/todo-service/stubs/calendar"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type CalendarHandler struct {
+	calendarPB.UnimplementedCalendarServiceServer
+	calendarUseCase CalendarUseCase
+}
+
+func NewCalendar(calendarUseCase CalendarUseCase) *CalendarHandler {
+	return &CalendarHandler{
+		calendarUseCase: calendarUseCase,
+	}
+}
+
+func (h *CalendarHandler) CreateFeedToken(ctx context.Context, req *calendarPB.CreateFeedTokenRequest) (*calendarPB.CreateFeedTokenResponse, error) {
+	payload := entity.CreateFeedTokenRequest{
+		UserID:     req.GetUserId(),
+		Name:       req.GetName(),
+		ActivityID: req.ActivityId,
+	}
+
+	data, err := h.calendarUseCase.CreateFeedToken(ctx, payload)
+	if errors.Is(err, entity.ErrForbidden) {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	if errors.Is(err, entity.ErrInvalidFeedToken) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if errors.Is(err, entity.ErrChildNotAllowed) {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	if err != nil && err.Error() == "sql: no rows in result set" {
+		return nil, status.Errorf(codes.NotFound, "data for activityId: %v", req.GetActivityId())
+	}
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Internal Server error: %v", err)
+	}
+
+	res := &calendarPB.CreateFeedTokenResponse{
+		Message:   "Success",
+		FeedToken: newFeedTokenResponse(data.FeedToken),
+		Token:     data.Token,
+		FeedUrl:   data.FeedURL,
+		CaldavUrl: data.CalDAVURL,
+	}
+
+	return res, nil
+}
+
+func (h *CalendarHandler) ListFeedTokens(ctx context.Context, req *calendarPB.ListFeedTokensRequest) (*calendarPB.ListFeedTokensResponse, error) {
+	data, err := h.calendarUseCase.GetAllFeedToken(ctx, req.GetUserId())
+	if errors.Is(err, entity.ErrForbidden) {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Internal Server error: %v", err)
+	}
+
+	res := &calendarPB.ListFeedTokensResponse{
+		Message:    "Success",
+		FeedTokens: []*calendarPB.FeedToken{},
+	}
+	for _, token := range data {
+		res.FeedTokens = append(res.FeedTokens, newFeedTokenResponse(token))
+	}
+
+	return res, nil
+}
+
+func (h *CalendarHandler) RevokeFeedToken(ctx context.Context, req *calendarPB.RevokeFeedTokenRequest) (*calendarPB.CalendarBaseResponse, error) {
+	err := h.calendarUseCase.RevokeFeedToken(ctx, req.GetUserId(), req.GetId())
+	if errors.Is(err, entity.ErrForbidden) {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	if err != nil && err.Error() == "data not found" {
+		return nil, status.Errorf(codes.NotFound, "data for feedTokenId: %v", req.GetId())
+	}
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Internal Server error: %v", err)
+	}
+
+	res := &calendarPB.CalendarBaseResponse{
+		Message: "Success",
+	}
+
+	return res, nil
+}
+
+func newFeedTokenResponse(token entity.FeedToken) *calendarPB.FeedToken {
+	return &calendarPB.FeedToken{
+		Id:         token.ID,
+		UserId:     token.UserID,
+		Name:       token.Name,
+		ActivityId: token.ActivityID,
+		CreatedAt:  timestamppb.New(token.CreatedAt),
+		LastUsedAt: toTimestamp(token.LastUsedAt),
+	}
+}
@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/digisata/todo-service/internal/entity"
+	"github.com/digisata/todo-service/internal/usecase"
+	"github.com/digisata/todo-service/pkg/interceptor"
+	"github.com/digisata/todo-service/pkg/logging"
+	calendarPB "github.com/digisata/todo-service/stubs/calendar"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type memFeedTokenRepository struct {
+	mu     sync.Mutex
+	tokens []entity.FeedToken
+}
+
+func (r *memFeedTokenRepository) Create(ctx context.Context, req entity.FeedToken) (entity.FeedToken, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	req.ID = fmt.Sprint(len(r.tokens) + 1)
+	r.tokens = append(r.tokens, req)
+
+	return req, nil
+}
+
+func (r *memFeedTokenRepository) GetAll(ctx context.Context, userID string) ([]entity.FeedToken, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var res []entity.FeedToken
+	for _, token := range r.tokens {
+		if token.UserID == userID {
+			res = append(res, token)
+		}
+	}
+
+	return res, nil
+}
+
+func (r *memFeedTokenRepository) Use(ctx context.Context, tokenHash string) (entity.FeedToken, error) {
+	return entity.FeedToken{}, fmt.Errorf("sql: no rows in result set")
+}
+
+func (r *memFeedTokenRepository) Revoke(ctx context.Context, userID, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, token := range r.tokens {
+		if token.UserID == userID && token.ID == id {
+			r.tokens = append(r.tokens[:i], r.tokens[i+1:]...)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("data not found")
+}
+
+// newCalendarClient serves the calendar service in memory behind the logging
+// interceptor, which sets the user of each call.
+func newCalendarClient(t *testing.T) (calendarPB.CalendarServiceClient, *memFeedTokenRepository) {
+	t.Helper()
+
+	repo := &memFeedTokenRepository{}
+	calendarUseCase := usecase.NewCalendar(repo, nil, nil, nil, "https://todo.example", entity.Quota{})
+	im := interceptor.NewInterceptorManager(zap.NewNop().Sugar(), logging.Config{}, nil)
+
+	lis := bufconn.Listen(1 << 20)
+	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(im.Logger))
+	calendarPB.RegisterCalendarServiceServer(grpcServer, NewCalendar(calendarUseCase))
+	go grpcServer.Serve(lis)
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return calendarPB.NewCalendarServiceClient(conn), repo
+}
+
+func asUser(userID string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), interceptor.UserIDHeader, userID)
+}
+
+func TestFeedTokensOfAnotherUser(t *testing.T) {
+	client, repo := newCalendarClient(t)
+
+	_, err := client.CreateFeedToken(asUser("bob"), &calendarPB.CreateFeedTokenRequest{UserId: "bob"})
+	if err != nil {
+		t.Fatalf("create own token: %v", err)
+	}
+
+	cases := map[string]context.Context{
+		"other user":   asUser("alice"),
+		"no caller":    context.Background(),
+		"empty caller": asUser(""),
+	}
+
+	for name, ctx := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := client.CreateFeedToken(ctx, &calendarPB.CreateFeedTokenRequest{UserId: "bob"})
+			if status.Code(err) != codes.PermissionDenied {
+				t.Errorf("create: got %v, want %v", err, codes.PermissionDenied)
+			}
+
+			_, err = client.ListFeedTokens(ctx, &calendarPB.ListFeedTokensRequest{UserId: "bob"})
+			if status.Code(err) != codes.PermissionDenied {
+				t.Errorf("list: got %v, want %v", err, codes.PermissionDenied)
+			}
+
+			_, err = client.RevokeFeedToken(ctx, &calendarPB.RevokeFeedTokenRequest{UserId: "bob", Id: "1"})
+			if status.Code(err) != codes.PermissionDenied {
+				t.Errorf("revoke: got %v, want %v", err, codes.PermissionDenied)
+			}
+		})
+	}
+
+	if len(repo.tokens) != 1 || repo.tokens[0].UserID != "bob" {
+		t.Errorf("tokens changed: %+v", repo.tokens)
+	}
+
+	res, err := client.ListFeedTokens(asUser("bob"), &calendarPB.ListFeedTokensRequest{UserId: "bob"})
+	if err != nil || len(res.GetFeedTokens()) != 1 {
+		t.Errorf("list own tokens: got %d, %v", len(res.GetFeedTokens()), err)
+	}
+}
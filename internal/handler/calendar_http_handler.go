@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/digisata/todo-service/internal/constant"
+	"github.com/digisata/todo-service/internal/entity"
+	"github.com/emersion/go-webdav/caldav"
+)
+
+// CalendarHTTPHandler serves the .ics subscription feed and the CalDAV
+// server. Both authenticate with a feed token: the feed carries it in the
+// URL, CalDAV clients send it as the Basic auth password.
+type CalendarHTTPHandler struct {
+	calendarUseCase CalendarUseCase
+	caldav          http.Handler
+}
+
+func NewCalendarHTTP(calendarUseCase CalendarUseCase) *CalendarHTTPHandler {
+	return &CalendarHTTPHandler{
+		calendarUseCase: calendarUseCase,
+		caldav: &caldav.Handler{
+			Backend: NewCalDAVBackend(calendarUseCase),
+			Prefix:  constant.CALDAV_PATH,
+		},
+	}
+}
+
+// Register adds the calendar routes to mux.
+func (h *CalendarHTTPHandler) Register(mux *http.ServeMux) {
+	mux.HandleFunc("GET "+constant.FEED_PATH+"/{file}", h.Feed)
+	mux.Handle("/.well-known/caldav", h.authenticate(h.caldav))
+	mux.Handle(constant.CALDAV_PATH+"/", h.authenticate(h.caldav))
+}
+
+func (h *CalendarHTTPHandler) Feed(w http.ResponseWriter, r *http.Request) {
+	secret, ok := strings.CutSuffix(r.PathValue("file"), ".ics")
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	token, err := h.calendarUseCase.AuthenticateFeedToken(r.Context(), secret)
+	if errors.Is(err, entity.ErrInvalidFeedToken) {
+		http.NotFound(w, r)
+		return
+	}
+
+	if err != nil {
+		http.Error(w, "Internal Server error", http.StatusInternalServerError)
+		return
+	}
+
+	// The feed only holds tasks with a due date, so it is small enough to
+	// build in memory and fail with a proper status.
+	var b bytes.Buffer
+	err = h.calendarUseCase.WriteFeed(r.Context(), token, &b)
+	if err != nil {
+		http.Error(w, "Internal Server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Length", strconv.Itoa(b.Len()))
+	w.Header().Set("Cache-Control", "private, max-age=300")
+	w.Write(b.Bytes())
+}
+
+func (h *CalendarHTTPHandler) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, secret, ok := r.BasicAuth()
+		if !ok {
+			unauthorized(w)
+			return
+		}
+
+		token, err := h.calendarUseCase.AuthenticateFeedToken(r.Context(), secret)
+		if errors.Is(err, entity.ErrInvalidFeedToken) {
+			unauthorized(w)
+			return
+		}
+
+		if err != nil {
+			http.Error(w, "Internal Server error", http.StatusInternalServerError)
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), feedTokenKey{}, token)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func unauthorized(w http.ResponseWriter) {
+	w.Header().Set("WWW-Authenticate", `Basic realm="todo-service", charset="UTF-8"`)
+	http.Error(w, "Unauthorized", http.StatusUnauthorized)
+}
@@ -0,0 +1,201 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	"github.com/digisata/todo-service/internal/constant"
+	"github.com/digisata/todo-service/internal/entity"
+	commentPB "github.com/digisata/todo-service/stubs/comment"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type CommentHandler struct {
+	commentPB.UnimplementedCommentServiceServer
+	commentUseCase CommentUseCase
+}
+
+func NewComment(commentUseCase CommentUseCase) *CommentHandler {
+	return &CommentHandler{
+		commentUseCase: commentUseCase,
+	}
+}
+
+func (h *CommentHandler) Create(ctx context.Context, req *commentPB.CreateCommentRequest) (*commentPB.CreateCommentResponse, error) {
+	payload := entity.CreateCommentRequest{
+		TaskID: req.GetTaskId(),
+		Body:   req.GetBody(),
+	}
+
+	data, err := h.commentUseCase.CreateComment(ctx, payload)
+	if errors.Is(err, entity.ErrInvalidComment) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if errors.Is(err, entity.ErrForbidden) {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	if err != nil && err.Error() == "sql: no rows in result set" {
+		return nil, status.Errorf(codes.NotFound, "data for taskId: %v", req.GetTaskId())
+	}
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Internal Server error: %v", err)
+	}
+
+	res := &commentPB.CreateCommentResponse{
+		Message: "Success",
+		Comment: newCommentResponse(data),
+	}
+
+	return res, nil
+}
+
+func (h *CommentHandler) Update(ctx context.Context, req *commentPB.UpdateCommentByIDRequest) (*commentPB.CommentBaseResponse, error) {
+	payload := entity.UpdateCommentRequest{
+		ID:   req.GetId(),
+		Body: req.GetBody(),
+	}
+
+	err := h.commentUseCase.UpdateComment(ctx, payload)
+	if errors.Is(err, entity.ErrInvalidComment) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if errors.Is(err, entity.ErrForbidden) {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	if err != nil && (err.Error() == "data not found" || err.Error() == "sql: no rows in result set") {
+		return nil, status.Errorf(codes.NotFound, "data for commentId: %v", req.GetId())
+	}
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Internal Server error: %v", err)
+	}
+
+	res := &commentPB.CommentBaseResponse{
+		Message: "Success",
+	}
+
+	return res, nil
+}
+
+func (h *CommentHandler) Delete(ctx context.Context, req *commentPB.DeleteCommentByIDRequest) (*commentPB.CommentBaseResponse, error) {
+	err := h.commentUseCase.DeleteComment(ctx, req.GetId())
+	if errors.Is(err, entity.ErrForbidden) {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	if err != nil && (err.Error() == "data not found" || err.Error() == "sql: no rows in result set") {
+		return nil, status.Errorf(codes.NotFound, "data for commentId: %v", req.GetId())
+	}
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Internal Server error: %v", err)
+	}
+
+	res := &commentPB.CommentBaseResponse{
+		Message: "Success",
+	}
+
+	return res, nil
+}
+
+func (h *CommentHandler) List(ctx context.Context, req *commentPB.ListCommentsRequest) (*commentPB.ListCommentsResponse, error) {
+	payload := entity.GetAllCommentRequest{
+		TaskID: req.GetTaskId(),
+		Cursor: req.Cursor,
+		Limit:  req.Limit,
+	}
+
+	data, paging, err := h.commentUseCase.GetAllComment(ctx, payload)
+	if errors.Is(err, entity.ErrInvalidCursor) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Internal Server error: %v", err)
+	}
+
+	res := &commentPB.ListCommentsResponse{
+		Message:  "Success",
+		Comments: []*commentPB.GetCommentByIDResponse{},
+		Paging:   newCommentPaging(paging),
+	}
+	for _, comment := range data {
+		res.Comments = append(res.Comments, newCommentResponse(comment))
+	}
+
+	return res, nil
+}
+
+func (h *CommentHandler) GetActivityFeed(ctx context.Context, req *commentPB.GetActivityFeedRequest) (*commentPB.GetActivityFeedResponse, error) {
+	payload := entity.GetFeedRequest{
+		ActivityID: req.GetActivityId(),
+		Cursor:     req.Cursor,
+		Limit:      req.Limit,
+	}
+
+	data, paging, err := h.commentUseCase.GetActivityFeed(ctx, payload)
+	if errors.Is(err, entity.ErrInvalidCursor) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Internal Server error: %v", err)
+	}
+
+	res := &commentPB.GetActivityFeedResponse{
+		Message: "Success",
+		Items:   []*commentPB.FeedItem{},
+		Paging:  newCommentPaging(paging),
+	}
+	for _, item := range data {
+		feedItem := &commentPB.FeedItem{
+			Kind:      item.Kind,
+			Id:        item.ID,
+			TaskId:    item.TaskID,
+			TaskTitle: item.TaskTitle,
+			Action:    item.Action,
+			CreatedAt: timestamppb.New(item.CreatedAt),
+		}
+
+		if item.Kind == constant.FEED_KIND_COMMENT {
+			feedItem.AuthorId = &item.AuthorID
+			feedItem.Body = &item.Body
+		}
+
+		res.Items = append(res.Items, feedItem)
+	}
+
+	return res, nil
+}
+
+func newCommentResponse(comment entity.Comment) *commentPB.GetCommentByIDResponse {
+	return &commentPB.GetCommentByIDResponse{
+		Id:        comment.ID,
+		TaskId:    comment.TaskID,
+		AuthorId:  comment.AuthorID,
+		Body:      comment.Body,
+		CreatedAt: timestamppb.New(comment.CreatedAt),
+		UpdatedAt: timestamppb.New(comment.UpdatedAt),
+		EditedAt:  toTimestamp(comment.EditedAt),
+	}
+}
+
+func newCommentPaging(paging entity.Paging) *commentPB.CommentPaging {
+	res := &commentPB.CommentPaging{
+		Count: paging.Count,
+	}
+
+	if paging.NextCursor != "" {
+		res.NextCursor = &paging.NextCursor
+	}
+
+	return res
+}
@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/digisata/todo-service/internal/entity"
+	"github.com/digisata/todo-service/internal/usecase"
+	"github.com/digisata/todo-service/pkg/interceptor"
+	"github.com/digisata/todo-service/pkg/logging"
+	commentPB "github.com/digisata/todo-service/stubs/comment"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type memCommentRepository struct {
+	mu       sync.Mutex
+	comments map[string]entity.Comment
+}
+
+func (r *memCommentRepository) Create(ctx context.Context, req entity.CreateCommentRequest) (entity.Comment, error) {
+	return entity.Comment{TaskID: req.TaskID, AuthorID: req.AuthorID, Body: req.Body}, nil
+}
+
+func (r *memCommentRepository) GetByID(ctx context.Context, id string) (entity.Comment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	comment, ok := r.comments[id]
+	if !ok {
+		return comment, sql.ErrNoRows
+	}
+
+	return comment, nil
+}
+
+func (r *memCommentRepository) Update(ctx context.Context, req entity.UpdateCommentRequest) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	comment := r.comments[req.ID]
+	comment.Body = req.Body
+	r.comments[req.ID] = comment
+
+	return nil
+}
+
+func (r *memCommentRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.comments, id)
+
+	return nil
+}
+
+func (r *memCommentRepository) GetAll(ctx context.Context, req entity.GetAllCommentRequest) ([]entity.Comment, entity.Paging, error) {
+	return nil, entity.Paging{}, nil
+}
+
+func (r *memCommentRepository) GetFeed(ctx context.Context, req entity.GetFeedRequest) ([]entity.FeedItem, entity.Paging, error) {
+	return nil, entity.Paging{}, nil
+}
+
+// newCommentClient serves the comment service in memory behind the logging
+// interceptor, which sets the user of each call.
+func newCommentClient(t *testing.T, repo *memCommentRepository) commentPB.CommentServiceClient {
+	t.Helper()
+
+	commentUseCase := usecase.NewComment(repo, nil)
+	im := interceptor.NewInterceptorManager(zap.NewNop().Sugar(), logging.Config{}, nil)
+
+	lis := bufconn.Listen(1 << 20)
+	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(im.Logger))
+	commentPB.RegisterCommentServiceServer(grpcServer, NewComment(commentUseCase))
+	go grpcServer.Serve(lis)
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return commentPB.NewCommentServiceClient(conn)
+}
+
+func TestCommentsOfAnotherAuthor(t *testing.T) {
+	repo := &memCommentRepository{comments: map[string]entity.Comment{
+		"1": {ID: "1", TaskID: "task", AuthorID: "bob", Body: "first"},
+	}}
+	client := newCommentClient(t, repo)
+
+	cases := map[string]context.Context{
+		"other user": asUser("alice"),
+		"no caller":  context.Background(),
+	}
+
+	for name, ctx := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := client.Update(ctx, &commentPB.UpdateCommentByIDRequest{Id: "1", Body: "changed"})
+			if status.Code(err) != codes.PermissionDenied {
+				t.Errorf("update: got %v, want %v", err, codes.PermissionDenied)
+			}
+
+			_, err = client.Delete(ctx, &commentPB.DeleteCommentByIDRequest{Id: "1"})
+			if status.Code(err) != codes.PermissionDenied {
+				t.Errorf("delete: got %v, want %v", err, codes.PermissionDenied)
+			}
+		})
+	}
+
+	// The author_id of the body does not make the caller its author
+	_, err := client.Create(context.Background(), &commentPB.CreateCommentRequest{TaskId: "task", AuthorId: "bob", Body: "as bob"})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("create without caller: got %v, want %v", err, codes.PermissionDenied)
+	}
+
+	if repo.comments["1"].Body != "first" {
+		t.Errorf("comment changed: %+v", repo.comments["1"])
+	}
+
+	_, err = client.Update(asUser("bob"), &commentPB.UpdateCommentByIDRequest{Id: "1", Body: "changed"})
+	if err != nil {
+		t.Errorf("update own comment: %v", err)
+	}
+
+	_, err = client.Delete(asUser("bob"), &commentPB.DeleteCommentByIDRequest{Id: "1"})
+	if err != nil {
+		t.Errorf("delete own comment: %v", err)
+	}
+}
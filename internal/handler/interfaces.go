@@ -2,6 +2,7 @@ package handler
 
 import (
 	"context"
+	"io"
 
 	"github.com/digisata/todo-service/internal/entity"
 )
@@ -14,6 +15,11 @@ type (
 		GetTask(ctx context.Context, id string) (entity.Task, error)
 		GetAllTaskByActivityID(ctx context.Context, req entity.GetAllTaskRequest) ([]entity.Task, entity.Paging, error)
 		DeleteTask(ctx context.Context, id string) error
+		ArchiveCompletedTasks(ctx context.Context, activityID string) (int64, error)
+		BulkCreateTask(ctx context.Context, req []entity.CreateTaskRequest) ([]entity.BulkTaskResult, error)
+		BulkDeleteTask(ctx context.Context, req entity.BulkTaskRequest) ([]entity.BulkTaskResult, error)
+		BulkCompleteTask(ctx context.Context, req entity.BulkTaskRequest) ([]entity.BulkTaskResult, error)
+		BulkMoveTask(ctx context.Context, req entity.BulkMoveTaskRequest) ([]entity.BulkTaskResult, error)
 	}
 
 	ActivityUseCase interface {
@@ -22,6 +28,10 @@ type (
 		GetActivity(ctx context.Context, id string) (entity.Activity, error)
 		GetAllActivity(ctx context.Context, req entity.GetAllActivityRequest) ([]entity.Activity, entity.Paging, error)
 		DeleteActivity(ctx context.Context, id string) error
+		GetAllActivityType(ctx context.Context) []entity.ActivityType
+		ArchiveActivity(ctx context.Context, id string) error
+		UnarchiveActivity(ctx context.Context, id string) error
+		WatchActivity(ctx context.Context, req entity.WatchActivityRequest, send func(entity.Event) error) error
 	}
 
 	TextUseCase interface {
@@ -30,5 +40,51 @@ type (
 		GetText(ctx context.Context, id string) (entity.Text, error)
 		GetAllTextByActivityID(ctx context.Context, req entity.GetAllTextRequest) ([]entity.Text, entity.Paging, error)
 		DeleteText(ctx context.Context, id string) error
+		GetAllTextRevision(ctx context.Context, req entity.GetAllTextRevisionRequest) ([]entity.TextRevision, entity.Paging, error)
+		GetTextRevision(ctx context.Context, req entity.GetTextRevisionRequest) (entity.TextRevision, error)
+		DiffTextRevision(ctx context.Context, req entity.DiffTextRevisionRequest) ([]entity.TextDiff, error)
+		RestoreTextRevision(ctx context.Context, req entity.GetTextRevisionRequest) error
+		JoinTextSession(ctx context.Context, textID string) (entity.TextEditor, error)
+		SubmitTextEdit(ctx context.Context, editor entity.TextEditor, req entity.TextEdit) error
+		LeaveTextSession(ctx context.Context, editor entity.TextEditor) error
+	}
+
+	SyncUseCase interface {
+		Sync(ctx context.Context, req entity.SyncRequest) (entity.SyncResponse, error)
+	}
+
+	CommentUseCase interface {
+		CreateComment(ctx context.Context, req entity.CreateCommentRequest) (entity.Comment, error)
+		UpdateComment(ctx context.Context, req entity.UpdateCommentRequest) error
+		DeleteComment(ctx context.Context, id string) error
+		GetAllComment(ctx context.Context, req entity.GetAllCommentRequest) ([]entity.Comment, entity.Paging, error)
+		GetActivityFeed(ctx context.Context, req entity.GetFeedRequest) ([]entity.FeedItem, entity.Paging, error)
+	}
+
+	AttachmentUseCase interface {
+		UploadAttachment(ctx context.Context, req entity.CreateAttachmentRequest, r io.Reader) (entity.Attachment, error)
+		DownloadAttachment(ctx context.Context, id string) (entity.Attachment, io.ReadCloser, error)
+		GetAllAttachment(ctx context.Context, req entity.GetAllAttachmentRequest) ([]entity.Attachment, error)
+		DeleteAttachment(ctx context.Context, id string) error
+	}
+
+	TransferUseCase interface {
+		Import(ctx context.Context, req entity.ImportRequest, r io.Reader) (entity.ImportResult, error)
+		ExportFile(req entity.ExportRequest) (entity.ExportFile, error)
+		Export(ctx context.Context, req entity.ExportRequest, w io.Writer) error
+	}
+
+	CalendarUseCase interface {
+		CreateFeedToken(ctx context.Context, req entity.CreateFeedTokenRequest) (entity.CreatedFeedToken, error)
+		GetAllFeedToken(ctx context.Context, userID string) ([]entity.FeedToken, error)
+		RevokeFeedToken(ctx context.Context, userID, id string) error
+		AuthenticateFeedToken(ctx context.Context, token string) (entity.FeedToken, error)
+		WriteFeed(ctx context.Context, token entity.FeedToken, w io.Writer) error
+		GetAllCalendar(ctx context.Context, token entity.FeedToken) ([]entity.Activity, error)
+		GetCalendar(ctx context.Context, token entity.FeedToken, activityID string) (entity.Activity, error)
+		GetAllCalendarTask(ctx context.Context, token entity.FeedToken, activityID string) ([]entity.Task, error)
+		GetCalendarTask(ctx context.Context, token entity.FeedToken, activityID, name string) (entity.Task, error)
+		PutCalendarTask(ctx context.Context, token entity.FeedToken, req entity.CalendarTask) (entity.Task, error)
+		DeleteCalendarTask(ctx context.Context, token entity.FeedToken, activityID, name string) error
 	}
 )
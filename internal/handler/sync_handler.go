@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/digisata/todo-service/internal/entity"
+	syncPB "github.com/digisata/todo-service/stubs/sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type SyncHandler struct {
+	syncPB.UnimplementedSyncServiceServer
+	syncUseCase SyncUseCase
+}
+
+func NewSync(syncUseCase SyncUseCase) *SyncHandler {
+	return &SyncHandler{
+		syncUseCase: syncUseCase,
+	}
+}
+
+func (h *SyncHandler) Sync(ctx context.Context, req *syncPB.SyncRequest) (*syncPB.SyncResponse, error) {
+	var payload entity.SyncRequest
+
+	if req.SinceToken != nil {
+		token, err := strconv.ParseInt(req.GetSinceToken(), 10, 64)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid since_token: %v", req.GetSinceToken())
+		}
+
+		payload.SinceToken = &token
+	}
+
+	if req.SinceToken != nil && req.SnapshotCursor != nil {
+		return nil, status.Error(codes.InvalidArgument, "snapshot_cursor cannot be sent with since_token")
+	}
+
+	payload.SnapshotCursor = req.SnapshotCursor
+	payload.Limit = req.Limit
+
+	for _, mutation := range req.GetMutations() {
+		mutationPayload := entity.SyncMutation{
+			ClientMutationID: mutation.GetClientMutationId(),
+			EntityType:       mutation.GetEntityType(),
+			Operation:        mutation.GetOperation(),
+			EntityID:         mutation.GetEntityId(),
+			ActivityID:       mutation.GetActivityId(),
+			Title:            mutation.Title,
+			Type:             mutation.Type,
+			IsActive:         mutation.IsActive,
+			Text:             mutation.Text,
+		}
+
+		if mutation.BaseUpdatedAt != nil {
+			baseUpdatedAt := mutation.GetBaseUpdatedAt().AsTime()
+			mutationPayload.BaseUpdatedAt = &baseUpdatedAt
+		}
+
+		if mutation.Priority != nil {
+			priority := int(*mutation.Priority)
+			mutationPayload.Priority = &priority
+		}
+
+		if mutation.Order != nil {
+			order := int(*mutation.Order)
+			mutationPayload.Order = &order
+		}
+
+		payload.Mutations = append(payload.Mutations, mutationPayload)
+	}
+
+	data, err := h.syncUseCase.Sync(ctx, payload)
+	if errors.Is(err, entity.ErrInvalidCursor) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Internal Server error: %v", err)
+	}
+
+	res := &syncPB.SyncResponse{
+		Message:    "Success",
+		Activities: []*syncPB.SyncActivity{},
+		Tasks:      []*syncPB.SyncTask{},
+		Texts:      []*syncPB.SyncText{},
+		Results:    []*syncPB.SyncMutationResult{},
+	}
+
+	if data.NextSnapshotCursor != "" {
+		res.NextSnapshotCursor = &data.NextSnapshotCursor
+	} else {
+		res.NextToken = strconv.FormatInt(data.NextToken, 10)
+	}
+
+	for _, activity := range data.Activities {
+		res.Activities = append(res.Activities, &syncPB.SyncActivity{
+			Id:         activity.ID,
+			Title:      activity.Title,
+			Type:       activity.Type,
+			CreatedAt:  timestamppb.New(activity.CreatedAt),
+			UpdatedAt:  timestamppb.New(activity.UpdatedAt),
+			DeletedAt:  toTimestamp(activity.DeletedAt),
+			ArchivedAt: toTimestamp(activity.ArchivedAt),
+		})
+	}
+
+	for _, task := range data.Tasks {
+		res.Tasks = append(res.Tasks, &syncPB.SyncTask{
+			Id:         task.ID,
+			ActivityId: task.ActivityID,
+			Title:      task.Title,
+			IsActive:   task.IsActive,
+			Priority:   int32(task.Priority),
+			Order:      int32(task.Order),
+			CreatedAt:  timestamppb.New(task.CreatedAt),
+			UpdatedAt:  timestamppb.New(task.UpdatedAt),
+			DeletedAt:  toTimestamp(task.DeletedAt),
+			ArchivedAt: toTimestamp(task.ArchivedAt),
+		})
+	}
+
+	for _, text := range data.Texts {
+		res.Texts = append(res.Texts, &syncPB.SyncText{
+			Id:         text.ID,
+			ActivityId: text.ActivityID,
+			Text:       text.Text,
+			CreatedAt:  timestamppb.New(text.CreatedAt),
+			UpdatedAt:  timestamppb.New(text.UpdatedAt),
+			DeletedAt:  toTimestamp(text.DeletedAt),
+		})
+	}
+
+	for _, result := range data.Results {
+		resultRes := &syncPB.SyncMutationResult{
+			ClientMutationId: result.ClientMutationID,
+			EntityId:         result.EntityID,
+			Status:           result.Status,
+		}
+
+		if result.Message != "" {
+			resultRes.Message = &result.Message
+		}
+
+		res.Results = append(res.Results, resultRes)
+	}
+
+	return res, nil
+}
+
+func toTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+
+	return timestamppb.New(*t)
+}
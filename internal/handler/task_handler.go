@@ -2,7 +2,9 @@ package handler
 
 import (
 	"context"
+	"errors"
 
+	"github.com/digisata/todo-service/internal/constant"
 	"github.com/digisata/todo-service/internal/entity"
 	taskPB "github.com/digisata/todo-service/stubs/task"
 
@@ -30,7 +32,24 @@ func (h *TaskHandler) Create(ctx context.Context, req *taskPB.CreateTaskRequest)
 		Priority:   int(req.GetPriority()),
 	}
 
+	if req.DueAt != nil {
+		dueAt := req.GetDueAt().AsTime()
+		payload.DueAt = &dueAt
+	}
+
 	err := h.taskUseCase.CreateTask(ctx, payload)
+	if err != nil && err.Error() == "sql: no rows in result set" {
+		return nil, status.Errorf(codes.NotFound, "data for activityId: %v", req.GetActivityId())
+	}
+
+	if errors.Is(err, entity.ErrChildNotAllowed) {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	if errors.Is(err, entity.ErrQuotaExceeded) {
+		return nil, status.Error(codes.ResourceExhausted, err.Error())
+	}
+
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Internal Server error: %v", err)
 	}
@@ -54,6 +73,11 @@ func (g *TaskHandler) Update(ctx context.Context, req *taskPB.UpdateTaskByIDRequ
 		payload.Priority = &priority
 	}
 
+	if req.DueAt != nil {
+		dueAt := req.GetDueAt().AsTime()
+		payload.DueAt = &dueAt
+	}
+
 	err := g.taskUseCase.UpdateTask(ctx, payload)
 	if err != nil && err.Error() == "data not found" {
 		return nil, status.Errorf(codes.NotFound, "data for userId: %v", req.GetId())
@@ -90,6 +114,11 @@ func (g *TaskHandler) BatchUpdate(ctx context.Context, req *taskPB.BatchUpdateTa
 			taskPayload.Order = &order
 		}
 
+		if task.DueAt != nil {
+			dueAt := task.GetDueAt().AsTime()
+			taskPayload.DueAt = &dueAt
+		}
+
 		payload = append(payload, taskPayload)
 	}
 
@@ -124,6 +153,8 @@ func (h *TaskHandler) Get(ctx context.Context, req *taskPB.GetTaskByIDRequest) (
 		Order:      int32(data.Order),
 		CreatedAt:  timestamppb.New(data.CreatedAt),
 		UpdatedAt:  timestamppb.New(data.UpdatedAt),
+		ArchivedAt: toTimestamp(data.ArchivedAt),
+		DueAt:      toTimestamp(data.DueAt),
 	}
 
 	return res, nil
@@ -140,6 +171,7 @@ func (g *TaskHandler) GetAllByUserID(ctx context.Context, req *taskPB.GetAllTask
 		IsOldest:     req.IsOldest,
 		IsAscending:  req.IsAscending,
 		IsDescending: req.IsDescending,
+		IsArchived:   req.IsArchived,
 	}
 
 	if req.Priority != nil {
@@ -171,6 +203,8 @@ func (g *TaskHandler) GetAllByUserID(ctx context.Context, req *taskPB.GetAllTask
 			Order:      int32(task.Order),
 			CreatedAt:  timestamppb.New(task.CreatedAt),
 			UpdatedAt:  timestamppb.New(task.UpdatedAt),
+			ArchivedAt: toTimestamp(task.ArchivedAt),
+			DueAt:      toTimestamp(task.DueAt),
 		}
 
 		res.Tasks = append(res.Tasks, data)
@@ -195,3 +229,139 @@ func (g *TaskHandler) Delete(ctx context.Context, req *taskPB.DeleteTaskByIDRequ
 
 	return res, nil
 }
+
+func (g *TaskHandler) ArchiveCompletedTasks(ctx context.Context, req *taskPB.ArchiveCompletedTasksRequest) (*taskPB.ArchiveCompletedTasksResponse, error) {
+	count, err := g.taskUseCase.ArchiveCompletedTasks(ctx, req.GetActivityId())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Internal Server error: %v", err)
+	}
+
+	res := &taskPB.ArchiveCompletedTasksResponse{
+		Message: "Success",
+		Count:   count,
+	}
+
+	return res, nil
+}
+
+func (g *TaskHandler) BulkCreate(ctx context.Context, req *taskPB.BulkCreateTaskRequest) (*taskPB.BulkTaskResponse, error) {
+	if len(req.GetTasks()) > constant.MAX_BULK_ITEMS {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d tasks are allowed", constant.MAX_BULK_ITEMS)
+	}
+
+	var payload []entity.CreateTaskRequest
+	for _, task := range req.GetTasks() {
+		taskPayload := entity.CreateTaskRequest{
+			ActivityID: task.GetActivityId(),
+			Title:      task.GetTitle(),
+			IsActive:   task.IsActive,
+			Priority:   int(task.GetPriority()),
+		}
+
+		if task.DueAt != nil {
+			dueAt := task.GetDueAt().AsTime()
+			taskPayload.DueAt = &dueAt
+		}
+
+		payload = append(payload, taskPayload)
+	}
+
+	data, err := g.taskUseCase.BulkCreateTask(ctx, payload)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Internal Server error: %v", err)
+	}
+
+	return newBulkTaskResponse(data), nil
+}
+
+func (g *TaskHandler) BulkDelete(ctx context.Context, req *taskPB.BulkTaskByIDsRequest) (*taskPB.BulkTaskResponse, error) {
+	if len(req.GetIds()) > constant.MAX_BULK_ITEMS {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d ids are allowed", constant.MAX_BULK_ITEMS)
+	}
+
+	payload := entity.BulkTaskRequest{
+		ActivityID: req.GetActivityId(),
+		IDs:        req.GetIds(),
+	}
+
+	data, err := g.taskUseCase.BulkDeleteTask(ctx, payload)
+	if err != nil && err.Error() == "data not found" {
+		return nil, status.Errorf(codes.NotFound, "data for activityId: %v", req.GetActivityId())
+	}
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Internal Server error: %v", err)
+	}
+
+	return newBulkTaskResponse(data), nil
+}
+
+func (g *TaskHandler) BulkComplete(ctx context.Context, req *taskPB.BulkTaskByIDsRequest) (*taskPB.BulkTaskResponse, error) {
+	if len(req.GetIds()) > constant.MAX_BULK_ITEMS {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d ids are allowed", constant.MAX_BULK_ITEMS)
+	}
+
+	payload := entity.BulkTaskRequest{
+		ActivityID: req.GetActivityId(),
+		IDs:        req.GetIds(),
+	}
+
+	data, err := g.taskUseCase.BulkCompleteTask(ctx, payload)
+	if err != nil && err.Error() == "data not found" {
+		return nil, status.Errorf(codes.NotFound, "data for activityId: %v", req.GetActivityId())
+	}
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Internal Server error: %v", err)
+	}
+
+	return newBulkTaskResponse(data), nil
+}
+
+func (g *TaskHandler) BulkMove(ctx context.Context, req *taskPB.BulkMoveTaskRequest) (*taskPB.BulkTaskResponse, error) {
+	if len(req.GetIds()) > constant.MAX_BULK_ITEMS {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d ids are allowed", constant.MAX_BULK_ITEMS)
+	}
+
+	payload := entity.BulkMoveTaskRequest{
+		ActivityID:       req.GetActivityId(),
+		IDs:              req.GetIds(),
+		TargetActivityID: req.GetTargetActivityId(),
+	}
+
+	data, err := g.taskUseCase.BulkMoveTask(ctx, payload)
+	if err != nil && err.Error() == "data not found" {
+		return nil, status.Errorf(codes.NotFound, "data for activityId: %v", req.GetTargetActivityId())
+	}
+
+	if errors.Is(err, entity.ErrChildNotAllowed) {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	if errors.Is(err, entity.ErrQuotaExceeded) {
+		return nil, status.Error(codes.ResourceExhausted, err.Error())
+	}
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Internal Server error: %v", err)
+	}
+
+	return newBulkTaskResponse(data), nil
+}
+
+func newBulkTaskResponse(data []entity.BulkTaskResult) *taskPB.BulkTaskResponse {
+	res := &taskPB.BulkTaskResponse{
+		Message: "Success",
+		Results: []*taskPB.BulkTaskResult{},
+	}
+
+	for _, result := range data {
+		res.Results = append(res.Results, &taskPB.BulkTaskResult{
+			Index:  int32(result.Index),
+			Id:     result.ID,
+			Status: result.Status,
+		})
+	}
+
+	return res
+}
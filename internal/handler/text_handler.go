@@ -2,7 +2,10 @@ package handler
 
 import (
 	"context"
+	"errors"
+	"io"
 
+	"github.com/digisata/todo-service/internal/constant"
 	"github.com/digisata/todo-service/internal/entity"
 	textPB "github.com/digisata/todo-service/stubs/text"
 
@@ -26,9 +29,26 @@ func (h *TextHandler) Create(ctx context.Context, req *textPB.CreateTextRequest)
 	payload := entity.CreateTextRequest{
 		ActivityID: req.GetActivityId(),
 		Text:       req.Text,
+		Format:     textFormat(req.GetFormat()),
 	}
 
 	err := h.textUseCase.CreateText(ctx, payload)
+	if err != nil && err.Error() == "sql: no rows in result set" {
+		return nil, status.Errorf(codes.NotFound, "data for activityId: %v", req.GetActivityId())
+	}
+
+	if errors.Is(err, entity.ErrInvalidTextFormat) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if errors.Is(err, entity.ErrChildNotAllowed) {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	if errors.Is(err, entity.ErrQuotaExceeded) {
+		return nil, status.Error(codes.ResourceExhausted, err.Error())
+	}
+
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Internal Server error: %v", err)
 	}
@@ -42,15 +62,28 @@ func (h *TextHandler) Create(ctx context.Context, req *textPB.CreateTextRequest)
 
 func (g *TextHandler) Update(ctx context.Context, req *textPB.UpdateTextByIDRequest) (*textPB.TextBaseResponse, error) {
 	payload := entity.UpdateTextRequest{
-		ID:   req.GetId(),
-		Text: req.Text,
+		ID:     req.GetId(),
+		Text:   req.Text,
+		Format: textFormat(req.GetFormat()),
 	}
 
 	err := g.textUseCase.UpdateText(ctx, payload)
+	if errors.Is(err, entity.ErrInvalidTextFormat) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if errors.Is(err, entity.ErrTextSessionOpen) {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
 	if err != nil && err.Error() == "data not found" {
 		return nil, status.Errorf(codes.NotFound, "data for userId: %v", req.GetId())
 	}
 
+	if errors.Is(err, entity.ErrQuotaExceeded) {
+		return nil, status.Error(codes.ResourceExhausted, err.Error())
+	}
+
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Internal Server error: %v", err)
 	}
@@ -76,6 +109,7 @@ func (h *TextHandler) Get(ctx context.Context, req *textPB.GetTextByIDRequest) (
 		Id:         data.ID,
 		ActivityId: data.ActivityID,
 		Text:       data.Text,
+		PlainText:  data.PlainText,
 		CreatedAt:  timestamppb.New(data.CreatedAt),
 		UpdatedAt:  timestamppb.New(data.UpdatedAt),
 	}
@@ -114,6 +148,7 @@ func (g *TextHandler) GetAllByUserID(ctx context.Context, req *textPB.GetAllText
 			Id:         text.ID,
 			ActivityId: text.ActivityID,
 			Text:       text.Text,
+			PlainText:  text.PlainText,
 			CreatedAt:  timestamppb.New(text.CreatedAt),
 			UpdatedAt:  timestamppb.New(text.UpdatedAt),
 		}
@@ -140,3 +175,305 @@ func (g *TextHandler) Delete(ctx context.Context, req *textPB.DeleteTextByIDRequ
 
 	return res, nil
 }
+
+func (h *TextHandler) ListTextRevisions(ctx context.Context, req *textPB.ListTextRevisionsRequest) (*textPB.ListTextRevisionsResponse, error) {
+	payload := entity.GetAllTextRevisionRequest{
+		TextID: req.GetTextId(),
+		Page:   req.Page,
+		Limit:  req.Limit,
+	}
+
+	data, paging, err := h.textUseCase.GetAllTextRevision(ctx, payload)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Internal Server error: %v", err)
+	}
+
+	res := &textPB.ListTextRevisionsResponse{
+		Message:   "Success",
+		Revisions: []*textPB.TextRevision{},
+		Paging: &textPB.TextPaging{
+			CurrentPage: paging.CurrentPage,
+			TotalPage:   paging.TotalPage,
+			Count:       paging.Count,
+		},
+	}
+	for _, revision := range data {
+		res.Revisions = append(res.Revisions, newTextRevisionResponse(revision))
+	}
+
+	return res, nil
+}
+
+func (h *TextHandler) GetTextRevision(ctx context.Context, req *textPB.GetTextRevisionRequest) (*textPB.TextRevision, error) {
+	payload := entity.GetTextRevisionRequest{
+		TextID:   req.GetTextId(),
+		Revision: int(req.GetRevision()),
+	}
+
+	data, err := h.textUseCase.GetTextRevision(ctx, payload)
+	if err != nil && err.Error() == "sql: no rows in result set" {
+		return nil, status.Errorf(codes.NotFound, "revision %v for textId: %v", req.GetRevision(), req.GetTextId())
+	}
+
+	if errors.Is(err, entity.ErrTextSessionOpen) {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Internal Server error: %v", err)
+	}
+
+	return newTextRevisionResponse(data), nil
+}
+
+func (h *TextHandler) DiffTextRevisions(ctx context.Context, req *textPB.DiffTextRevisionsRequest) (*textPB.DiffTextRevisionsResponse, error) {
+	payload := entity.DiffTextRevisionRequest{
+		TextID:       req.GetTextId(),
+		FromRevision: int(req.GetFromRevision()),
+	}
+
+	if req.ToRevision != nil {
+		toRevision := int(req.GetToRevision())
+		payload.ToRevision = &toRevision
+	}
+
+	data, err := h.textUseCase.DiffTextRevision(ctx, payload)
+	if err != nil && err.Error() == "sql: no rows in result set" {
+		return nil, status.Errorf(codes.NotFound, "revision for textId: %v", req.GetTextId())
+	}
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Internal Server error: %v", err)
+	}
+
+	res := &textPB.DiffTextRevisionsResponse{
+		Message: "Success",
+		Diffs:   []*textPB.TextDiff{},
+	}
+	for _, diff := range data {
+		res.Diffs = append(res.Diffs, &textPB.TextDiff{
+			Operation: diff.Operation,
+			Text:      diff.Text,
+		})
+	}
+
+	return res, nil
+}
+
+func (h *TextHandler) RestoreTextRevision(ctx context.Context, req *textPB.RestoreTextRevisionRequest) (*textPB.TextBaseResponse, error) {
+	payload := entity.GetTextRevisionRequest{
+		TextID:   req.GetTextId(),
+		Revision: int(req.GetRevision()),
+	}
+
+	err := h.textUseCase.RestoreTextRevision(ctx, payload)
+	if err != nil && err.Error() == "data not found" {
+		return nil, status.Errorf(codes.NotFound, "revision %v for textId: %v", req.GetRevision(), req.GetTextId())
+	}
+
+	if errors.Is(err, entity.ErrTextSessionOpen) {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Internal Server error: %v", err)
+	}
+
+	res := &textPB.TextBaseResponse{
+		Message: "Success",
+	}
+
+	return res, nil
+}
+
+func (h *TextHandler) CollaborateText(stream textPB.TextService_CollaborateTextServer) error {
+	ctx := stream.Context()
+
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	join := req.GetJoin()
+	if join == nil {
+		return status.Error(codes.InvalidArgument, "first message must be join")
+	}
+
+	editor, err := h.textUseCase.JoinTextSession(ctx, join.GetTextId())
+	if err != nil && err.Error() == "sql: no rows in result set" {
+		return status.Errorf(codes.NotFound, "data for textId: %v", join.GetTextId())
+	}
+
+	if err != nil {
+		return status.Errorf(codes.Internal, "Internal Server error: %v", err)
+	}
+
+	err = stream.Send(&textPB.CollaborateTextResponse{
+		Payload: &textPB.CollaborateTextResponse_Snapshot{
+			Snapshot: &textPB.TextSessionSnapshot{
+				TextId:   editor.TextID,
+				Revision: int32(editor.Revision),
+				Text:     editor.Text,
+			},
+		},
+	})
+	if err != nil {
+		h.textUseCase.LeaveTextSession(ctx, editor)
+		return err
+	}
+
+	// Edits are received on their own goroutine; everything sent to the client
+	// goes through the editor's channel so acks stay in order with other edits.
+	recvErr := make(chan error, 1)
+	go func() {
+		for {
+			req, err := stream.Recv()
+			if err != nil {
+				recvErr <- err
+				return
+			}
+
+			edit := req.GetEdit()
+			if edit == nil {
+				recvErr <- status.Error(codes.InvalidArgument, "expected edit")
+				return
+			}
+
+			err = h.textUseCase.SubmitTextEdit(ctx, editor, newTextEditPayload(edit))
+			if err != nil {
+				recvErr <- err
+				return
+			}
+		}
+	}()
+
+	err = h.streamTextEdits(ctx, stream, editor, recvErr)
+
+	leaveErr := h.textUseCase.LeaveTextSession(ctx, editor)
+	if err == nil && leaveErr != nil {
+		return status.Errorf(codes.Internal, "Internal Server error: %v", leaveErr)
+	}
+
+	return err
+}
+
+func (h *TextHandler) streamTextEdits(ctx context.Context, stream textPB.TextService_CollaborateTextServer, editor entity.TextEditor, recvErr <-chan error) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-recvErr:
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+
+			if errors.Is(err, entity.ErrInvalidTextEdit) {
+				return status.Error(codes.InvalidArgument, err.Error())
+			}
+
+			if errors.Is(err, entity.ErrTextSessionClosed) {
+				return status.Error(codes.Aborted, err.Error())
+			}
+
+			if errors.Is(err, entity.ErrQuotaExceeded) {
+				return status.Error(codes.ResourceExhausted, err.Error())
+			}
+
+			if _, ok := status.FromError(err); ok {
+				return err
+			}
+
+			return status.Errorf(codes.Internal, "Internal Server error: %v", err)
+		case edit, ok := <-editor.Edits:
+			if !ok {
+				return status.Error(codes.Aborted, entity.ErrTextSessionClosed.Error())
+			}
+
+			res := &textPB.CollaborateTextResponse{}
+			if edit.Ack {
+				res.Payload = &textPB.CollaborateTextResponse_Ack{
+					Ack: &textPB.TextEditAck{
+						Revision: int32(edit.Revision),
+					},
+				}
+			} else {
+				res.Payload = &textPB.CollaborateTextResponse_Edit{
+					Edit: newTextEditResponse(edit),
+				}
+			}
+
+			err := stream.Send(res)
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func newTextEditPayload(edit *textPB.TextEdit) entity.TextEdit {
+	payload := entity.TextEdit{
+		Revision: int(edit.GetRevision()),
+	}
+
+	for _, component := range edit.GetOperation() {
+		var c entity.TextOperationComponent
+		switch component.GetComponent().(type) {
+		case *textPB.TextOperationComponent_Retain:
+			c.Retain = int(component.GetRetain())
+		case *textPB.TextOperationComponent_Insert:
+			c.Insert = component.GetInsert()
+		case *textPB.TextOperationComponent_Delete:
+			c.Delete = int(component.GetDelete())
+		}
+
+		payload.Operation = append(payload.Operation, c)
+	}
+
+	return payload
+}
+
+func newTextEditResponse(edit entity.TextEdit) *textPB.TextEdit {
+	res := &textPB.TextEdit{
+		Revision:  int32(edit.Revision),
+		Operation: []*textPB.TextOperationComponent{},
+	}
+
+	for _, c := range edit.Operation {
+		component := &textPB.TextOperationComponent{}
+		switch {
+		case c.Retain > 0:
+			component.Component = &textPB.TextOperationComponent_Retain{Retain: int32(c.Retain)}
+		case c.Insert != "":
+			component.Component = &textPB.TextOperationComponent_Insert{Insert: c.Insert}
+		default:
+			component.Component = &textPB.TextOperationComponent_Delete{Delete: int32(c.Delete)}
+		}
+
+		res.Operation = append(res.Operation, component)
+	}
+
+	return res
+}
+
+func newTextRevisionResponse(revision entity.TextRevision) *textPB.TextRevision {
+	return &textPB.TextRevision{
+		TextId:    revision.TextID,
+		Revision:  int32(revision.Revision),
+		Text:      revision.Text,
+		PlainText: revision.PlainText,
+		CreatedAt: timestamppb.New(revision.CreatedAt),
+	}
+}
+
+func textFormat(format textPB.TextFormat) string {
+	switch format {
+	case textPB.TextFormat_TEXT_FORMAT_UNSPECIFIED:
+		return ""
+	case textPB.TextFormat_TEXT_FORMAT_HTML:
+		return constant.TEXT_FORMAT_HTML
+	case textPB.TextFormat_TEXT_FORMAT_MARKDOWN:
+		return constant.TEXT_FORMAT_MARKDOWN
+	default:
+		return format.String()
+	}
+}
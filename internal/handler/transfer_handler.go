@@ -0,0 +1,223 @@
+package handler
+
+import (
+	"bufio"
+	"errors"
+	"io"
+
+	"github.com/digisata/todo-service/internal/constant"
+	"github.com/digisata/todo-service/internal/entity"
+	transferPB "github.com/digisata/todo-service/stubs/transfer"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type TransferHandler struct {
+	transferPB.UnimplementedTransferServiceServer
+	transferUseCase TransferUseCase
+}
+
+func NewTransfer(transferUseCase TransferUseCase) *TransferHandler {
+	return &TransferHandler{
+		transferUseCase: transferUseCase,
+	}
+}
+
+func (h *TransferHandler) Import(stream transferPB.TransferService_ImportServer) error {
+	ctx := stream.Context()
+
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	info := req.GetInfo()
+	if info == nil {
+		return status.Error(codes.InvalidArgument, "first message must be info")
+	}
+
+	payload := entity.ImportRequest{
+		Format: importFormat(info.GetFormat()),
+		Name:   info.GetName(),
+		DryRun: info.GetDryRun(),
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		for {
+			req, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				pw.Close()
+				return
+			}
+
+			if err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+
+			if req.GetInfo() != nil {
+				pw.CloseWithError(status.Error(codes.InvalidArgument, "expected chunk"))
+				return
+			}
+
+			_, err = pw.Write(req.GetChunk())
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	data, err := h.transferUseCase.Import(ctx, payload, pr)
+	pr.Close()
+
+	if errors.Is(err, entity.ErrImportTooLarge) {
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+
+	if errors.Is(err, entity.ErrInvalidImport) || errors.Is(err, entity.ErrInvalidActivity) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if errors.Is(err, entity.ErrQuotaExceeded) {
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+
+	if _, ok := status.FromError(err); ok && err != nil {
+		return err
+	}
+
+	if err != nil {
+		return status.Errorf(codes.Internal, "Internal Server error: %v", err)
+	}
+
+	res := &transferPB.ImportResponse{
+		Message:    "Success",
+		DryRun:     data.DryRun,
+		Activities: []*transferPB.ImportedActivity{},
+		Tasks:      int32(data.Tasks),
+		Texts:      int32(data.Texts),
+	}
+	for _, activity := range data.Activities {
+		res.Activities = append(res.Activities, &transferPB.ImportedActivity{
+			Id:             activity.ID,
+			Title:          activity.Title,
+			Type:           activity.Type,
+			Tasks:          int32(activity.Tasks),
+			CompletedTasks: int32(activity.CompletedTasks),
+			Texts:          int32(activity.Texts),
+		})
+	}
+
+	return stream.SendAndClose(res)
+}
+
+func (h *TransferHandler) Export(req *transferPB.ExportRequest, stream transferPB.TransferService_ExportServer) error {
+	payload := entity.ExportRequest{
+		Format:          exportFormat(req.GetFormat()),
+		ActivityID:      req.GetActivityId(),
+		IncludeArchived: req.GetIncludeArchived(),
+	}
+
+	file, err := h.transferUseCase.ExportFile(payload)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = stream.Send(&transferPB.ExportResponse{
+		Payload: &transferPB.ExportResponse_Info{
+			Info: &transferPB.ExportFileInfo{
+				FileName:    file.Name,
+				ContentType: file.ContentType,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	w := bufio.NewWriterSize(exportChunkWriter{stream: stream}, constant.EXPORT_CHUNK_SIZE)
+
+	err = h.transferUseCase.Export(stream.Context(), payload, w)
+	if err == nil {
+		err = w.Flush()
+	}
+
+	if err != nil && (err.Error() == "sql: no rows in result set" || err.Error() == "data not found") {
+		return status.Errorf(codes.NotFound, "data for activityId: %v", req.GetActivityId())
+	}
+
+	if errors.Is(err, entity.ErrForbidden) {
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	if _, ok := status.FromError(err); ok && err != nil {
+		return err
+	}
+
+	if err != nil {
+		return status.Errorf(codes.Internal, "Internal Server error: %v", err)
+	}
+
+	return nil
+}
+
+// exportChunkWriter sends writes as chunk messages of at most
+// constant.EXPORT_CHUNK_SIZE bytes.
+type exportChunkWriter struct {
+	stream transferPB.TransferService_ExportServer
+}
+
+func (w exportChunkWriter) Write(p []byte) (int, error) {
+	var written int
+	for len(p) > 0 {
+		n := min(len(p), constant.EXPORT_CHUNK_SIZE)
+
+		err := w.stream.Send(&transferPB.ExportResponse{
+			Payload: &transferPB.ExportResponse_Chunk{
+				Chunk: p[:n],
+			},
+		})
+		if err != nil {
+			return written, err
+		}
+
+		written += n
+		p = p[n:]
+	}
+
+	return written, nil
+}
+
+func importFormat(format transferPB.ImportFormat) string {
+	switch format {
+	case transferPB.ImportFormat_IMPORT_FORMAT_TODOIST_CSV:
+		return constant.IMPORT_FORMAT_TODOIST_CSV
+	case transferPB.ImportFormat_IMPORT_FORMAT_MICROSOFT_TODO_JSON:
+		return constant.IMPORT_FORMAT_MICROSOFT_TODO_JSON
+	case transferPB.ImportFormat_IMPORT_FORMAT_GOOGLE_TASKS_JSON:
+		return constant.IMPORT_FORMAT_GOOGLE_TASKS_JSON
+	case transferPB.ImportFormat_IMPORT_FORMAT_ICAL:
+		return constant.IMPORT_FORMAT_ICAL
+	case transferPB.ImportFormat_IMPORT_FORMAT_JSON:
+		return constant.IMPORT_FORMAT_JSON
+	default:
+		return format.String()
+	}
+}
+
+func exportFormat(format transferPB.ExportFormat) string {
+	switch format {
+	case transferPB.ExportFormat_EXPORT_FORMAT_JSON:
+		return constant.EXPORT_FORMAT_JSON
+	case transferPB.ExportFormat_EXPORT_FORMAT_CSV:
+		return constant.EXPORT_FORMAT_CSV
+	case transferPB.ExportFormat_EXPORT_FORMAT_MARKDOWN:
+		return constant.EXPORT_FORMAT_MARKDOWN
+	case transferPB.ExportFormat_EXPORT_FORMAT_ICAL:
+		return constant.EXPORT_FORMAT_ICAL
+	default:
+		return format.String()
+	}
+}
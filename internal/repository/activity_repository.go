@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/Masterminds/squirrel"
+	"github.com/digisata/todo-service/internal/constant"
 	"github.com/digisata/todo-service/internal/entity"
 	"github.com/digisata/todo-service/internal/shared"
 	"github.com/digisata/todo-service/pkg/postgres"
@@ -19,44 +20,82 @@ func NewActivity(db *postgres.Postgres) *ActivityRepository {
 	return &ActivityRepository{db}
 }
 
-func (r ActivityRepository) Create(ctx context.Context, req entity.CreateActivityRequest) error {
-	var activityId string
+// Create inserts the activity with its default children and returns its id.
+// It joins the transaction of ctx when there is one.
+func (r ActivityRepository) Create(ctx context.Context, req entity.CreateActivityRequest) (_ string, err error) {
+	defer r.ObserveQuery(ctx, "activity", "Create", &err)()
 
-	now := time.Now().UTC()
-	sql, args, err := r.Builder.
-		Insert("activities").
-		Columns("title, type, created_at, updated_at").
-		Values(req.Title, req.Type, now, now).Suffix("RETURNING id").
-		ToSql()
-	if err != nil {
-		return err
-	}
+	var activityId string
 
-	err = r.Db.QueryRowContext(ctx, sql, args...).Scan(&activityId)
-	if err != nil {
-		return err
-	}
+	err = r.WithTx(ctx, func(ctx context.Context) error {
+		tx := r.Executor(ctx)
 
-	if req.Type == "activity_text" {
+		now := time.Now().UTC()
 		sql, args, err := r.Builder.
-			Insert("texts").
-			Columns("text, activity_id, created_at, updated_at").
-			Values(`<p class="default-text">Fill your note ....</p>`, activityId, now, now).
+			Insert("activities").
+			Columns("title, type, user_id, created_at, updated_at").
+			Values(req.Title, req.Type, req.UserID, now, now).Suffix("RETURNING id").
 			ToSql()
 		if err != nil {
 			return err
 		}
 
-		_, err = r.Db.ExecContext(ctx, sql, args...)
+		err = tx.QueryRowContext(ctx, sql, args...).Scan(&activityId)
 		if err != nil {
 			return err
 		}
+
+		// Insert the default children declared by the activity type
+		if len(req.DefaultTasks) > 0 {
+			insertQuery := r.Builder.
+				Insert("tasks").
+				Columns("title, activity_id, is_active, priority, created_at, updated_at")
+			for _, title := range req.DefaultTasks {
+				insertQuery = insertQuery.Values(title, activityId, true, 0, now, now)
+			}
+
+			sql, args, err = insertQuery.ToSql()
+			if err != nil {
+				return err
+			}
+
+			_, err = tx.ExecContext(ctx, sql, args...)
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(req.DefaultTexts) > 0 {
+			insertQuery := r.Builder.
+				Insert("texts").
+				Columns("text, plain_text, activity_id, created_at, updated_at")
+			for _, text := range req.DefaultTexts {
+				insertQuery = insertQuery.Values(text.Text, text.PlainText, activityId, now, now)
+			}
+
+			sql, args, err = insertQuery.ToSql()
+			if err != nil {
+				return err
+			}
+
+			_, err = tx.ExecContext(ctx, sql, args...)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return "", err
 	}
 
-	return nil
+	return activityId, nil
 }
 
-func (r ActivityRepository) Update(ctx context.Context, req entity.UpdateActivityRequest) error {
+func (r ActivityRepository) Update(ctx context.Context, req entity.UpdateActivityRequest) (err error) {
+	defer r.ObserveQuery(ctx, "activity", "Update", &err)()
+
 	tx, err := r.Db.Begin()
 	if err != nil {
 		return err
@@ -101,16 +140,18 @@ func (r ActivityRepository) Update(ctx context.Context, req entity.UpdateActivit
 	return nil
 }
 
-func (r ActivityRepository) GetAll(ctx context.Context, req entity.GetAllActivityRequest) ([]entity.Activity, entity.Paging, error) {
+func (r ActivityRepository) GetAll(ctx context.Context, req entity.GetAllActivityRequest) (_ []entity.Activity, _ entity.Paging, err error) {
+	defer r.ObserveQuery(ctx, "activity", "GetAll", &err)()
+
 	var (
 		data   []entity.Activity
 		paging entity.Paging
 	)
 
 	baseQuery := r.Builder.
-		Select("id, title, type, created_at, updated_at").
-		From("activities").
-		Where(squirrel.Eq{"deleted_at": nil})
+		Select("a.id, a.title, a.type, a.created_at, a.updated_at, a.archived_at").
+		From("activities a").
+		Where(squirrel.Eq{"a.deleted_at": nil})
 
 	// Clone the base query for counting total rows
 	countQuery := r.Builder.
@@ -118,6 +159,17 @@ func (r ActivityRepository) GetAll(ctx context.Context, req entity.GetAllActivit
 		From("activities a").
 		Where(squirrel.Eq{"a.deleted_at": nil})
 
+	// Archived activities are hidden unless explicitly requested
+	if req.IncludeArchived == nil || !*req.IncludeArchived {
+		baseQuery = baseQuery.Where(squirrel.Eq{"a.archived_at": nil})
+		countQuery = countQuery.Where(squirrel.Eq{"a.archived_at": nil})
+	}
+
+	if req.UserID != nil {
+		baseQuery = baseQuery.Where(squirrel.Eq{"a.user_id": *req.UserID})
+		countQuery = countQuery.Where(squirrel.Eq{"a.user_id": *req.UserID})
+	}
+
 	// Apply search filter if present
 	if req.Search != nil {
 		searchPattern := fmt.Sprintf("%%%s%%", *req.Search)
@@ -125,6 +177,9 @@ func (r ActivityRepository) GetAll(ctx context.Context, req entity.GetAllActivit
 		countQuery = countQuery.Where(squirrel.ILike{"a.title": searchPattern})
 	}
 
+	// A stable order keeps pages from overlapping
+	baseQuery = baseQuery.OrderBy("a.created_at ASC", "a.id ASC")
+
 	// Get the total count of rows that match the query
 	totalRowsSql, totalRowsArgs, err := countQuery.ToSql()
 	if err != nil {
@@ -173,7 +228,7 @@ func (r ActivityRepository) GetAll(ctx context.Context, req entity.GetAllActivit
 
 	for rows.Next() {
 		var activity entity.Activity
-		if err := rows.Scan(&activity.ID, &activity.Title, &activity.Type, &activity.CreatedAt, &activity.UpdatedAt); err != nil {
+		if err := rows.Scan(&activity.ID, &activity.Title, &activity.Type, &activity.CreatedAt, &activity.UpdatedAt, &activity.ArchivedAt); err != nil {
 			return data, paging, err
 		}
 		data = append(data, activity)
@@ -182,11 +237,13 @@ func (r ActivityRepository) GetAll(ctx context.Context, req entity.GetAllActivit
 	return data, paging, nil
 }
 
-func (r ActivityRepository) GetByID(ctx context.Context, id string) (entity.Activity, error) {
+func (r ActivityRepository) GetByID(ctx context.Context, id string) (_ entity.Activity, err error) {
+	defer r.ObserveQuery(ctx, "activity", "GetByID", &err)()
+
 	var data entity.Activity
 
 	sql, args, err := r.Builder.
-		Select("id, title, type,created_at, updated_at").
+		Select("id, title, type, user_id, created_at, updated_at, archived_at").
 		From("activities").
 		Where(squirrel.Eq{"id": id}).
 		Where(squirrel.Eq{"deleted_at": nil}).
@@ -196,7 +253,7 @@ func (r ActivityRepository) GetByID(ctx context.Context, id string) (entity.Acti
 	}
 
 	row := r.Db.QueryRowContext(ctx, sql, args...)
-	err = row.Scan(&data.ID, &data.Title, &data.Type, &data.CreatedAt, &data.UpdatedAt)
+	err = row.Scan(&data.ID, &data.Title, &data.Type, &data.UserID, &data.CreatedAt, &data.UpdatedAt, &data.ArchivedAt)
 	if err != nil {
 		return data, err
 	}
@@ -204,7 +261,71 @@ func (r ActivityRepository) GetByID(ctx context.Context, id string) (entity.Acti
 	return data, nil
 }
 
-func (r ActivityRepository) Delete(ctx context.Context, id string) error {
+// CountByUser counts the activities of a user that are not deleted,
+// archived ones included; without a user it counts the ones with no owner.
+// It first takes a transaction-level advisory lock on the user, so inside a
+// transaction a concurrent count for the same user waits until that
+// transaction has inserted its activities and ended.
+func (r ActivityRepository) CountByUser(ctx context.Context, userID *string) (_ int, err error) {
+	defer r.ObserveQuery(ctx, "activity", "CountByUser", &err)()
+
+	var count int
+
+	_, err = r.Executor(ctx).ExecContext(ctx, "SELECT pg_advisory_xact_lock(hashtext($1))", "activities:"+shared.Deref(userID))
+	if err != nil {
+		return count, err
+	}
+
+	sql, args, err := r.Builder.
+		Select("COUNT(*)").
+		From("activities").
+		Where(squirrel.Eq{"user_id": userID}).
+		Where(squirrel.Eq{"deleted_at": nil}).
+		ToSql()
+	if err != nil {
+		return count, err
+	}
+
+	err = r.Executor(ctx).QueryRowContext(ctx, sql, args...).Scan(&count)
+	if err != nil {
+		return count, err
+	}
+
+	return count, nil
+}
+
+// CountChildren counts the live tasks or texts of an activity.
+func (r ActivityRepository) CountChildren(ctx context.Context, activityID, child string) (_ int, err error) {
+	defer r.ObserveQuery(ctx, "activity", "CountChildren", &err)()
+
+	var count int
+
+	table := "tasks"
+	if child == constant.ENTITY_TEXT {
+		table = "texts"
+	}
+
+	sql, args, err := r.Builder.
+		Select("COUNT(*)").
+		From(table).
+		Where(squirrel.Eq{"activity_id": activityID}).
+		Where(squirrel.Eq{"deleted_at": nil}).
+		ToSql()
+	if err != nil {
+		return count, err
+	}
+
+	err = r.Executor(ctx).QueryRowContext(ctx, sql, args...).Scan(&count)
+	if err != nil {
+		return count, err
+	}
+
+	return count, nil
+}
+
+func (r ActivityRepository) Delete(ctx context.Context, id string) (err error) {
+	defer r.ObserveQuery(ctx, "activity", "Delete", &err)()
+
 	tx, err := r.Db.Begin()
 	if err != nil {
 		return err
@@ -250,3 +371,50 @@ func (r ActivityRepository) Delete(ctx context.Context, id string) error {
 
 	return nil
 }
+
+func (r ActivityRepository) Archive(ctx context.Context, id string) (err error) {
+	defer r.ObserveQuery(ctx, "activity", "Archive", &err)()
+
+	now := time.Now().UTC()
+
+	return r.setArchivedAt(ctx, id, &now)
+}
+
+func (r ActivityRepository) Unarchive(ctx context.Context, id string) (err error) {
+	defer r.ObserveQuery(ctx, "activity", "Unarchive", &err)()
+
+	return r.setArchivedAt(ctx, id, nil)
+}
+
+func (r ActivityRepository) setArchivedAt(ctx context.Context, id string, archivedAt *time.Time) error {
+	archiveValue := map[string]interface{}{
+		"archived_at": archivedAt,
+		"updated_at":  time.Now().UTC(),
+	}
+
+	sql, args, err := r.Builder.
+		Update("activities").
+		SetMap(archiveValue).
+		Where(squirrel.Eq{"id": id}).
+		Where(squirrel.Eq{"deleted_at": nil}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	res, err := r.Db.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("data not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/digisata/todo-service/internal/entity"
+	"github.com/digisata/todo-service/pkg/postgres"
+)
+
+type AttachmentRepository struct {
+	*postgres.Postgres
+}
+
+func NewAttachment(db *postgres.Postgres) *AttachmentRepository {
+	return &AttachmentRepository{db}
+}
+
+func (r AttachmentRepository) Create(ctx context.Context, req entity.Attachment) (err error) {
+	defer r.ObserveQuery(ctx, "attachment", "Create", &err)()
+
+	now := time.Now().UTC()
+	sql, args, err := r.Builder.
+		Insert("attachments").
+		Columns("id, entity_type, entity_id, file_name, content_type, size, checksum, storage_key, created_at, updated_at").
+		Values(req.ID, req.EntityType, req.EntityID, req.FileName, req.ContentType, req.Size, req.Checksum, req.StorageKey, now, now).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.Db.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r AttachmentRepository) GetAll(ctx context.Context, req entity.GetAllAttachmentRequest) (_ []entity.Attachment, err error) {
+	defer r.ObserveQuery(ctx, "attachment", "GetAll", &err)()
+
+	var data []entity.Attachment
+
+	sql, args, err := r.Builder.
+		Select("id, entity_type, entity_id, file_name, content_type, size, checksum, storage_key, created_at, updated_at").
+		From("attachments").
+		Where(squirrel.Eq{"entity_type": req.EntityType}).
+		Where(squirrel.Eq{"entity_id": req.EntityID}).
+		Where(squirrel.Eq{"deleted_at": nil}).
+		OrderBy("created_at ASC").
+		ToSql()
+	if err != nil {
+		return data, err
+	}
+
+	rows, err := r.Db.QueryContext(ctx, sql, args...)
+	if err != nil {
+		return data, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var attachment entity.Attachment
+		err := rows.Scan(
+			&attachment.ID,
+			&attachment.EntityType,
+			&attachment.EntityID,
+			&attachment.FileName,
+			&attachment.ContentType,
+			&attachment.Size,
+			&attachment.Checksum,
+			&attachment.StorageKey,
+			&attachment.CreatedAt,
+			&attachment.UpdatedAt,
+		)
+		if err != nil {
+			return data, err
+		}
+
+		data = append(data, attachment)
+	}
+
+	return data, rows.Err()
+}
+
+func (r AttachmentRepository) GetByID(ctx context.Context, id string) (_ entity.Attachment, err error) {
+	defer r.ObserveQuery(ctx, "attachment", "GetByID", &err)()
+
+	var data entity.Attachment
+
+	sql, args, err := r.Builder.
+		Select("id, entity_type, entity_id, file_name, content_type, size, checksum, storage_key, created_at, updated_at").
+		From("attachments").
+		Where(squirrel.Eq{"id": id}).
+		Where(squirrel.Eq{"deleted_at": nil}).
+		ToSql()
+	if err != nil {
+		return data, err
+	}
+
+	err = r.Db.QueryRowContext(ctx, sql, args...).Scan(
+		&data.ID,
+		&data.EntityType,
+		&data.EntityID,
+		&data.FileName,
+		&data.ContentType,
+		&data.Size,
+		&data.Checksum,
+		&data.StorageKey,
+		&data.CreatedAt,
+		&data.UpdatedAt,
+	)
+	if err != nil {
+		return data, err
+	}
+
+	return data, nil
+}
+
+func (r AttachmentRepository) Delete(ctx context.Context, id string) (err error) {
+	defer r.ObserveQuery(ctx, "attachment", "Delete", &err)()
+
+	sql, args, err := r.Builder.
+		Update("attachments").
+		Set("deleted_at", time.Now().UTC()).
+		Where(squirrel.Eq{"id": id}).
+		Where(squirrel.Eq{"deleted_at": nil}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	res, err := r.Db.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("data not found")
+	}
+
+	return nil
+}
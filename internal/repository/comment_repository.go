@@ -0,0 +1,343 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/digisata/todo-service/internal/constant"
+	"github.com/digisata/todo-service/internal/entity"
+	"github.com/digisata/todo-service/internal/shared"
+	"github.com/digisata/todo-service/pkg/postgres"
+)
+
+type CommentRepository struct {
+	*postgres.Postgres
+}
+
+func NewComment(db *postgres.Postgres) *CommentRepository {
+	return &CommentRepository{db}
+}
+
+func (r CommentRepository) Create(ctx context.Context, req entity.CreateCommentRequest) (_ entity.Comment, err error) {
+	defer r.ObserveQuery(ctx, "comment", "Create", &err)()
+
+	now := time.Now().UTC()
+	data := entity.Comment{
+		TaskID:    req.TaskID,
+		AuthorID:  req.AuthorID,
+		Body:      req.Body,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	sql, args, err := r.Builder.
+		Insert("comments").
+		Columns("task_id, author_id, body, created_at, updated_at").
+		Values(req.TaskID, req.AuthorID, req.Body, now, now).
+		Suffix("RETURNING id").
+		ToSql()
+	if err != nil {
+		return data, err
+	}
+
+	err = r.Db.QueryRowContext(ctx, sql, args...).Scan(&data.ID)
+	if err != nil {
+		return data, err
+	}
+
+	return data, nil
+}
+
+func (r CommentRepository) GetByID(ctx context.Context, id string) (_ entity.Comment, err error) {
+	defer r.ObserveQuery(ctx, "comment", "GetByID", &err)()
+
+	var data entity.Comment
+
+	sql, args, err := r.Builder.
+		Select("id, task_id, author_id, body, created_at, updated_at, edited_at").
+		From("comments").
+		Where(squirrel.Eq{"id": id}).
+		Where(squirrel.Eq{"deleted_at": nil}).
+		ToSql()
+	if err != nil {
+		return data, err
+	}
+
+	err = r.Db.QueryRowContext(ctx, sql, args...).Scan(
+		&data.ID,
+		&data.TaskID,
+		&data.AuthorID,
+		&data.Body,
+		&data.CreatedAt,
+		&data.UpdatedAt,
+		&data.EditedAt,
+	)
+	if err != nil {
+		return data, err
+	}
+
+	return data, nil
+}
+
+func (r CommentRepository) Update(ctx context.Context, req entity.UpdateCommentRequest) (err error) {
+	defer r.ObserveQuery(ctx, "comment", "Update", &err)()
+
+	now := time.Now().UTC()
+	sql, args, err := r.Builder.
+		Update("comments").
+		Set("body", req.Body).
+		Set("edited_at", now).
+		Set("updated_at", now).
+		Where(squirrel.Eq{"id": req.ID}).
+		Where(squirrel.Eq{"deleted_at": nil}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	res, err := r.Db.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("data not found")
+	}
+
+	return nil
+}
+
+func (r CommentRepository) Delete(ctx context.Context, id string) (err error) {
+	defer r.ObserveQuery(ctx, "comment", "Delete", &err)()
+
+	sql, args, err := r.Builder.
+		Update("comments").
+		Set("deleted_at", time.Now().UTC()).
+		Where(squirrel.Eq{"id": id}).
+		Where(squirrel.Eq{"deleted_at": nil}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	res, err := r.Db.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("data not found")
+	}
+
+	return nil
+}
+
+// GetAll lists the comments of a task oldest first, continuing after
+// req.After. One extra row is read to tell whether another page exists.
+func (r CommentRepository) GetAll(ctx context.Context, req entity.GetAllCommentRequest) (_ []entity.Comment, _ entity.Paging, err error) {
+	defer r.ObserveQuery(ctx, "comment", "GetAll", &err)()
+
+	var (
+		data   []entity.Comment
+		paging entity.Paging
+	)
+
+	limit := shared.CursorLimit(req.Limit, constant.DEFAULT_CURSOR_LIMIT, constant.MAX_CURSOR_LIMIT)
+
+	baseQuery := r.Builder.
+		Select("id, task_id, author_id, body, created_at, updated_at, edited_at").
+		From("comments").
+		Where(squirrel.Eq{"task_id": req.TaskID}).
+		Where(squirrel.Eq{"deleted_at": nil}).
+		OrderBy("created_at ASC", "id ASC").
+		Limit(uint64(limit) + 1)
+
+	countQuery := r.Builder.
+		Select("COUNT(*)").
+		From("comments").
+		Where(squirrel.Eq{"task_id": req.TaskID}).
+		Where(squirrel.Eq{"deleted_at": nil})
+
+	if req.After != nil {
+		baseQuery = baseQuery.Where("(created_at, id) > (?, ?)", req.After.CreatedAt, req.After.ID)
+	}
+
+	totalRowsSql, totalRowsArgs, err := countQuery.ToSql()
+	if err != nil {
+		return data, paging, err
+	}
+
+	err = r.Db.QueryRowContext(ctx, totalRowsSql, totalRowsArgs...).Scan(&paging.Count)
+	if err != nil {
+		return data, paging, err
+	}
+
+	sql, args, err := baseQuery.ToSql()
+	if err != nil {
+		return data, paging, err
+	}
+
+	rows, err := r.Db.QueryContext(ctx, sql, args...)
+	if err != nil {
+		return data, paging, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var comment entity.Comment
+		err := rows.Scan(
+			&comment.ID,
+			&comment.TaskID,
+			&comment.AuthorID,
+			&comment.Body,
+			&comment.CreatedAt,
+			&comment.UpdatedAt,
+			&comment.EditedAt,
+		)
+		if err != nil {
+			return data, paging, err
+		}
+
+		data = append(data, comment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return data, paging, err
+	}
+
+	if len(data) > int(limit) {
+		data = data[:limit]
+		last := data[len(data)-1]
+		paging.NextCursor = shared.EncodeCursor(entity.KeysetCursor{
+			CreatedAt: last.CreatedAt,
+			Kind:      constant.FEED_KIND_COMMENT,
+			ID:        last.ID,
+		})
+	}
+
+	return data, paging, nil
+}
+
+// GetFeed merges task lifecycle events and comments of an activity newest
+// first, continuing before req.Before. The feed is only paged by cursor, so
+// paging.Count stays unset.
+func (r CommentRepository) GetFeed(ctx context.Context, req entity.GetFeedRequest) (_ []entity.FeedItem, _ entity.Paging, err error) {
+	defer r.ObserveQuery(ctx, "comment", "GetFeed", &err)()
+
+	var (
+		data   []entity.FeedItem
+		paging entity.Paging
+	)
+
+	limit := shared.CursorLimit(req.Limit, constant.DEFAULT_CURSOR_LIMIT, constant.MAX_CURSOR_LIMIT)
+
+	comments, commentsArgs, err := squirrel.
+		Select(
+			fmt.Sprintf("'%s' AS kind", constant.FEED_KIND_COMMENT),
+			"c.id::text AS id",
+			"c.task_id::text AS task_id",
+			"t.title AS task_title",
+			fmt.Sprintf("'%s' AS action", constant.EVENT_ACTION_COMMENTED),
+			"c.author_id",
+			"c.body",
+			"c.created_at",
+		).
+		From("comments c").
+		Join("tasks t ON t.id = c.task_id").
+		Where(squirrel.Eq{"t.activity_id": req.ActivityID}).
+		Where(squirrel.Eq{"c.deleted_at": nil}).
+		ToSql()
+	if err != nil {
+		return data, paging, err
+	}
+
+	events := squirrel.
+		Select(
+			fmt.Sprintf("'%s' AS kind", constant.FEED_KIND_EVENT),
+			"e.id::text AS id",
+			"e.entity_id::text AS task_id",
+			"t.title AS task_title",
+			"e.action",
+			"'' AS author_id",
+			"'' AS body",
+			"e.created_at",
+		).
+		From("activity_events e").
+		Join("tasks t ON t.id = e.entity_id").
+		Where(squirrel.Eq{"e.activity_id": req.ActivityID}).
+		Where(squirrel.Eq{"e.entity_type": constant.ENTITY_TASK}).
+		Where(squirrel.Eq{"e.action": []string{
+			constant.EVENT_ACTION_CREATED,
+			constant.EVENT_ACTION_COMPLETED,
+			constant.EVENT_ACTION_REORDERED,
+		}}).
+		Suffix("UNION ALL "+comments, commentsArgs...)
+
+	baseQuery := r.Builder.
+		Select("kind, id, task_id, task_title, action, author_id, body, created_at").
+		FromSelect(events, "feed").
+		OrderBy("created_at DESC", "kind DESC", "id DESC").
+		Limit(uint64(limit) + 1)
+
+	if req.Before != nil {
+		baseQuery = baseQuery.Where("(created_at, kind, id) < (?, ?, ?)", req.Before.CreatedAt, req.Before.Kind, req.Before.ID)
+	}
+
+	sql, args, err := baseQuery.ToSql()
+	if err != nil {
+		return data, paging, err
+	}
+
+	rows, err := r.Db.QueryContext(ctx, sql, args...)
+	if err != nil {
+		return data, paging, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item entity.FeedItem
+		err := rows.Scan(
+			&item.Kind,
+			&item.ID,
+			&item.TaskID,
+			&item.TaskTitle,
+			&item.Action,
+			&item.AuthorID,
+			&item.Body,
+			&item.CreatedAt,
+		)
+		if err != nil {
+			return data, paging, err
+		}
+
+		data = append(data, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return data, paging, err
+	}
+
+	if len(data) > int(limit) {
+		data = data[:limit]
+		last := data[len(data)-1]
+		paging.NextCursor = shared.EncodeCursor(entity.KeysetCursor{
+			CreatedAt: last.CreatedAt,
+			Kind:      last.Kind,
+			ID:        last.ID,
+		})
+	}
+
+	return data, paging, nil
+}
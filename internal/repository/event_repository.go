@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/digisata/todo-service/internal/entity"
+	"github.com/digisata/todo-service/pkg/postgres"
+)
+
+type EventRepository struct {
+	*postgres.Postgres
+}
+
+func NewEvent(db *postgres.Postgres) *EventRepository {
+	return &EventRepository{db}
+}
+
+// GetAll returns the events after req.Cursor. Events of transactions that
+// may still be running are held back until they have all committed, so no
+// event can later appear before a cursor already handed out. heldBack
+// reports committed events that wait for an older transaction, of any
+// activity, to end; nothing notifies when that happens.
+func (r EventRepository) GetAll(ctx context.Context, req entity.GetAllEventRequest) (_ []entity.Event, heldBack bool, err error) {
+	defer r.ObserveQuery(ctx, "event", "GetAll", &err)()
+
+	var data []entity.Event
+
+	// Rows come in xact_id order, so the final ones are all before the
+	// first one that is held back.
+	sql, args, err := r.Builder.
+		Select("id, xact_id::text::bigint, activity_id, entity_type, entity_id, action, created_at").
+		Column("xact_id < pg_snapshot_xmin(pg_current_snapshot())").
+		From("activity_events").
+		Where(squirrel.Eq{"activity_id": req.ActivityID}).
+		Where("(xact_id, id) > (?::text::xid8, ?)", req.Cursor.XactID, req.Cursor.ID).
+		OrderBy("xact_id ASC", "id ASC").
+		Limit(uint64(req.Limit)).
+		ToSql()
+	if err != nil {
+		return data, false, err
+	}
+
+	rows, err := r.Db.QueryContext(ctx, sql, args...)
+	if err != nil {
+		return data, false, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			event entity.Event
+			final bool
+		)
+		err := rows.Scan(
+			&event.ID,
+			&event.XactID,
+			&event.ActivityID,
+			&event.EntityType,
+			&event.EntityID,
+			&event.Action,
+			&event.CreatedAt,
+			&final,
+		)
+		if err != nil {
+			return data, false, err
+		}
+
+		if !final {
+			return data, true, rows.Err()
+		}
+
+		data = append(data, event)
+	}
+
+	return data, false, rows.Err()
+}
+
+// GetCurrentCursor is the cursor of "now": events of transactions that are
+// still running come after it. Some events committed just before the call can
+// come after it too.
+func (r EventRepository) GetCurrentCursor(ctx context.Context) (_ entity.EventCursor, err error) {
+	defer r.ObserveQuery(ctx, "event", "GetCurrentCursor", &err)()
+
+	var cursor entity.EventCursor
+
+	err = r.Db.QueryRowContext(ctx, "SELECT pg_snapshot_xmin(pg_current_snapshot())::text::bigint").Scan(&cursor.XactID)
+	if err != nil {
+		return cursor, err
+	}
+
+	return cursor, nil
+}
@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/digisata/todo-service/internal/entity"
+	"github.com/digisata/todo-service/pkg/postgres"
+)
+
+type FeedTokenRepository struct {
+	*postgres.Postgres
+}
+
+func NewFeedToken(db *postgres.Postgres) *FeedTokenRepository {
+	return &FeedTokenRepository{db}
+}
+
+func (r FeedTokenRepository) Create(ctx context.Context, req entity.FeedToken) (_ entity.FeedToken, err error) {
+	defer r.ObserveQuery(ctx, "feed_token", "Create", &err)()
+
+	req.CreatedAt = time.Now().UTC()
+
+	sql, args, err := r.Builder.
+		Insert("feed_tokens").
+		Columns("user_id, name, activity_id, token_hash, created_at").
+		Values(req.UserID, req.Name, req.ActivityID, req.TokenHash, req.CreatedAt).
+		Suffix("RETURNING id").
+		ToSql()
+	if err != nil {
+		return req, err
+	}
+
+	err = r.Db.QueryRowContext(ctx, sql, args...).Scan(&req.ID)
+	if err != nil {
+		return req, err
+	}
+
+	return req, nil
+}
+
+// GetAll lists the tokens of a user that are not revoked, newest first.
+func (r FeedTokenRepository) GetAll(ctx context.Context, userID string) (_ []entity.FeedToken, err error) {
+	defer r.ObserveQuery(ctx, "feed_token", "GetAll", &err)()
+
+	var data []entity.FeedToken
+
+	sql, args, err := r.Builder.
+		Select("id, user_id, name, activity_id, created_at, last_used_at").
+		From("feed_tokens").
+		Where(squirrel.Eq{"user_id": userID}).
+		Where(squirrel.Eq{"revoked_at": nil}).
+		OrderBy("created_at DESC").
+		ToSql()
+	if err != nil {
+		return data, err
+	}
+
+	rows, err := r.Db.QueryContext(ctx, sql, args...)
+	if err != nil {
+		return data, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var token entity.FeedToken
+		err := rows.Scan(
+			&token.ID,
+			&token.UserID,
+			&token.Name,
+			&token.ActivityID,
+			&token.CreatedAt,
+			&token.LastUsedAt,
+		)
+		if err != nil {
+			return data, err
+		}
+
+		data = append(data, token)
+	}
+
+	return data, rows.Err()
+}
+
+// Use looks a live token up by its hash and records that it was used.
+func (r FeedTokenRepository) Use(ctx context.Context, tokenHash string) (_ entity.FeedToken, err error) {
+	defer r.ObserveQuery(ctx, "feed_token", "Use", &err)()
+
+	var data entity.FeedToken
+
+	sql, args, err := r.Builder.
+		Update("feed_tokens").
+		Set("last_used_at", time.Now().UTC()).
+		Where(squirrel.Eq{"token_hash": tokenHash}).
+		Where(squirrel.Eq{"revoked_at": nil}).
+		Suffix("RETURNING id, user_id, name, activity_id, created_at, last_used_at").
+		ToSql()
+	if err != nil {
+		return data, err
+	}
+
+	err = r.Db.QueryRowContext(ctx, sql, args...).Scan(
+		&data.ID,
+		&data.UserID,
+		&data.Name,
+		&data.ActivityID,
+		&data.CreatedAt,
+		&data.LastUsedAt,
+	)
+	if err != nil {
+		return data, err
+	}
+
+	return data, nil
+}
+
+func (r FeedTokenRepository) Revoke(ctx context.Context, userID, id string) (err error) {
+	defer r.ObserveQuery(ctx, "feed_token", "Revoke", &err)()
+
+	sql, args, err := r.Builder.
+		Update("feed_tokens").
+		Set("revoked_at", time.Now().UTC()).
+		Where(squirrel.Eq{"id": id}).
+		Where(squirrel.Eq{"user_id": userID}).
+		Where(squirrel.Eq{"revoked_at": nil}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	res, err := r.Db.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("data not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,470 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/digisata/todo-service/internal/constant"
+	"github.com/digisata/todo-service/internal/entity"
+	"github.com/digisata/todo-service/internal/shared"
+	"github.com/digisata/todo-service/pkg/postgres"
+)
+
+var syncTables = map[string]string{
+	constant.ENTITY_ACTIVITY: "activities",
+	constant.ENTITY_TASK:     "tasks",
+	constant.ENTITY_TEXT:     "texts",
+}
+
+type SyncRepository struct {
+	*postgres.Postgres
+}
+
+func NewSync(db *postgres.Postgres) *SyncRepository {
+	return &SyncRepository{db}
+}
+
+// GetLastToken returns the oldest transaction that may still be running.
+// Every event of an older transaction is committed, so changes up to the
+// token are final; events of later transactions are left to the next token,
+// whatever order they commit in.
+func (r SyncRepository) GetLastToken(ctx context.Context) (_ int64, err error) {
+	defer r.ObserveQuery(ctx, "sync", "GetLastToken", &err)()
+
+	var token int64
+
+	err = r.Db.QueryRowContext(ctx, "SELECT pg_snapshot_xmin(pg_current_snapshot())::text::bigint").Scan(&token)
+	if err != nil {
+		return token, err
+	}
+
+	return token, nil
+}
+
+// GetChanges returns every row of the user touched by an event of a
+// transaction in [Since, Until), deleted rows included. Without Since it
+// returns a page of the live rows of the user: the activities after req.After
+// in ID order, with their tasks and texts.
+func (r SyncRepository) GetChanges(ctx context.Context, req entity.GetChangesRequest) (_ entity.Changes, err error) {
+	defer r.ObserveQuery(ctx, "sync", "GetChanges", &err)()
+
+	var data entity.Changes
+
+	// Tasks and texts belong to the user through their activity
+	userActivities := squirrel.Expr("activity_id IN (SELECT id FROM activities WHERE user_id IS NULL)")
+	if req.UserID != nil {
+		userActivities = squirrel.Expr("activity_id IN (SELECT id FROM activities WHERE user_id = ?)", *req.UserID)
+	}
+
+	activityQuery := r.Builder.
+		Select("id, title, type, created_at, updated_at, deleted_at, archived_at").
+		From("activities").
+		Where(squirrel.Eq{"user_id": req.UserID})
+
+	taskQuery := r.Builder.
+		Select("id, title, activity_id, is_active, priority, order_position, created_at, updated_at, deleted_at, archived_at").
+		From("tasks")
+
+	textQuery := r.Builder.
+		Select("id, text, activity_id, created_at, updated_at, deleted_at").
+		From("texts")
+
+	if req.Since == nil {
+		limit := shared.CursorLimit(req.Limit, constant.DEFAULT_CURSOR_LIMIT, constant.MAX_CURSOR_LIMIT)
+
+		activityQuery = activityQuery.
+			Where(squirrel.Eq{"deleted_at": nil}).
+			OrderBy("id ASC").
+			Limit(uint64(limit) + 1)
+
+		if req.After != nil {
+			activityQuery = activityQuery.Where(squirrel.Gt{"id": req.After.ActivityID})
+		}
+
+		data.Activities, err = r.getActivities(ctx, activityQuery)
+		if err != nil {
+			return data, err
+		}
+
+		if len(data.Activities) > int(limit) {
+			data.Activities = data.Activities[:limit]
+			data.NextCursor = shared.EncodeSnapshotCursor(entity.SnapshotCursor{
+				Token:      req.Until,
+				ActivityID: data.Activities[len(data.Activities)-1].ID,
+			})
+		}
+
+		if len(data.Activities) == 0 {
+			return data, nil
+		}
+
+		activityIDs := make([]string, 0, len(data.Activities))
+		for _, activity := range data.Activities {
+			activityIDs = append(activityIDs, activity.ID)
+		}
+
+		taskQuery = taskQuery.
+			Where(squirrel.Eq{"activity_id": activityIDs}).
+			Where(squirrel.Eq{"deleted_at": nil})
+		textQuery = textQuery.
+			Where(squirrel.Eq{"activity_id": activityIDs}).
+			Where(squirrel.Eq{"deleted_at": nil})
+	} else {
+		changedIDs := "id IN (SELECT entity_id FROM activity_events WHERE entity_type = ? AND xact_id >= ?::text::xid8 AND xact_id < ?::text::xid8)"
+		activityQuery = activityQuery.Where(squirrel.Expr(changedIDs, constant.ENTITY_ACTIVITY, *req.Since, req.Until))
+		taskQuery = taskQuery.Where(squirrel.Expr(changedIDs, constant.ENTITY_TASK, *req.Since, req.Until)).Where(userActivities)
+		textQuery = textQuery.Where(squirrel.Expr(changedIDs, constant.ENTITY_TEXT, *req.Since, req.Until)).Where(userActivities)
+
+		data.Activities, err = r.getActivities(ctx, activityQuery)
+		if err != nil {
+			return data, err
+		}
+	}
+
+	data.Tasks, err = r.getTasks(ctx, taskQuery)
+	if err != nil {
+		return data, err
+	}
+
+	data.Texts, err = r.getTexts(ctx, textQuery)
+	if err != nil {
+		return data, err
+	}
+
+	return data, nil
+}
+
+// ApplyMutations applies client mutations in a single transaction, joining the
+// one of ctx when there is one. A mutation only goes through when the row has
+// not changed on the server since BaseUpdatedAt; otherwise it is reported as a
+// conflict and skipped.
+func (r SyncRepository) ApplyMutations(ctx context.Context, req []entity.SyncMutation) (data []entity.SyncMutationResult, err error) {
+	defer r.ObserveQuery(ctx, "sync", "ApplyMutations", &err)()
+
+	err = r.WithTx(ctx, func(ctx context.Context) error {
+		tx := r.Executor(ctx)
+
+		for _, mutation := range req {
+			var (
+				result entity.SyncMutationResult
+				err    error
+			)
+
+			switch mutation.Operation {
+			case constant.SYNC_OPERATION_CREATE:
+				result, err = r.create(ctx, tx, mutation)
+			default:
+				result, err = r.modify(ctx, tx, mutation)
+			}
+			if err != nil {
+				return err
+			}
+
+			data = append(data, result)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func (r SyncRepository) create(ctx context.Context, tx postgres.Executor, mutation entity.SyncMutation) (entity.SyncMutationResult, error) {
+	result := entity.SyncMutationResult{
+		ClientMutationID: mutation.ClientMutationID,
+		EntityID:         mutation.EntityID,
+	}
+	table := syncTables[mutation.EntityType]
+
+	exists, err := r.exists(ctx, tx, r.Builder.Select("COUNT(*)").From(table).Where(squirrel.Eq{"id": mutation.EntityID}))
+	if err != nil {
+		return result, err
+	}
+
+	if exists {
+		result.Status = constant.SYNC_STATUS_CONFLICT
+		result.Message = "entity already exists"
+		return result, nil
+	}
+
+	now := time.Now().UTC()
+	insertValue := map[string]interface{}{
+		"id":         mutation.EntityID,
+		"created_at": now,
+		"updated_at": now,
+	}
+
+	switch mutation.EntityType {
+	case constant.ENTITY_ACTIVITY:
+		insertValue["title"] = shared.Deref(mutation.Title)
+		insertValue["type"] = shared.Deref(mutation.Type)
+		insertValue["user_id"] = mutation.UserID
+	case constant.ENTITY_TASK:
+		insertValue["title"] = shared.Deref(mutation.Title)
+		insertValue["activity_id"] = mutation.ActivityID
+		insertValue["is_active"] = mutation.IsActive == nil || *mutation.IsActive
+		insertValue["priority"] = shared.Deref(mutation.Priority)
+		if mutation.Order != nil {
+			insertValue["order_position"] = *mutation.Order
+		}
+	case constant.ENTITY_TEXT:
+		insertValue["text"] = shared.Deref(mutation.Text)
+		insertValue["plain_text"] = shared.Deref(mutation.PlainText)
+		insertValue["activity_id"] = mutation.ActivityID
+	}
+
+	if mutation.EntityType != constant.ENTITY_ACTIVITY {
+		exists, err := r.exists(ctx, tx, r.Builder.
+			Select("COUNT(*)").
+			From("activities").
+			Where(squirrel.Eq{"id": mutation.ActivityID}).
+			Where(squirrel.Eq{"deleted_at": nil}))
+		if err != nil {
+			return result, err
+		}
+
+		if !exists {
+			result.Status = constant.SYNC_STATUS_NOT_FOUND
+			result.Message = "activity not found"
+			return result, nil
+		}
+	}
+
+	sql, args, err := r.Builder.
+		Insert(table).
+		SetMap(insertValue).
+		ToSql()
+	if err != nil {
+		return result, err
+	}
+
+	_, err = tx.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return result, err
+	}
+
+	result.Status = constant.SYNC_STATUS_APPLIED
+
+	return result, nil
+}
+
+func (r SyncRepository) modify(ctx context.Context, tx postgres.Executor, mutation entity.SyncMutation) (entity.SyncMutationResult, error) {
+	var (
+		updatedAt time.Time
+		deletedAt *time.Time
+	)
+
+	result := entity.SyncMutationResult{
+		ClientMutationID: mutation.ClientMutationID,
+		EntityID:         mutation.EntityID,
+	}
+	table := syncTables[mutation.EntityType]
+
+	query, args, err := r.Builder.
+		Select("updated_at, deleted_at").
+		From(table).
+		Where(squirrel.Eq{"id": mutation.EntityID}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return result, err
+	}
+
+	err = tx.QueryRowContext(ctx, query, args...).Scan(&updatedAt, &deletedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		result.Status = constant.SYNC_STATUS_NOT_FOUND
+		result.Message = "entity not found"
+		return result, nil
+	}
+
+	if err != nil {
+		return result, err
+	}
+
+	if deletedAt != nil {
+		result.Status = constant.SYNC_STATUS_CONFLICT
+		result.Message = "entity was deleted on the server"
+		return result, nil
+	}
+
+	if mutation.BaseUpdatedAt != nil && updatedAt.After(*mutation.BaseUpdatedAt) {
+		result.Status = constant.SYNC_STATUS_CONFLICT
+		result.Message = fmt.Sprintf("entity was modified on the server at %s", updatedAt.Format(time.RFC3339Nano))
+		return result, nil
+	}
+
+	var setValue map[string]interface{}
+	if mutation.Operation == constant.SYNC_OPERATION_DELETE {
+		setValue = map[string]interface{}{
+			"deleted_at": time.Now().UTC(),
+		}
+	} else {
+		switch mutation.EntityType {
+		case constant.ENTITY_ACTIVITY:
+			setValue = shared.CreateUpdateValueMap(entity.UpdateActivityRequest{
+				Title: shared.Deref(mutation.Title),
+				Type:  shared.Deref(mutation.Type),
+			})
+		case constant.ENTITY_TASK:
+			setValue = shared.CreateUpdateValueMap(entity.UpdateTaskRequest{
+				Title:    mutation.Title,
+				IsActive: mutation.IsActive,
+				Priority: mutation.Priority,
+				Order:    mutation.Order,
+			})
+		case constant.ENTITY_TEXT:
+			setValue = shared.CreateUpdateValueMap(entity.UpdateTextRequest{
+				Text:      mutation.Text,
+				PlainText: mutation.PlainText,
+			})
+		}
+	}
+
+	query, args, err = r.Builder.
+		Update(table).
+		SetMap(setValue).
+		Where(squirrel.Eq{"id": mutation.EntityID}).
+		ToSql()
+	if err != nil {
+		return result, err
+	}
+
+	_, err = tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return result, err
+	}
+
+	result.Status = constant.SYNC_STATUS_APPLIED
+
+	return result, nil
+}
+
+func (r SyncRepository) exists(ctx context.Context, tx postgres.Executor, query squirrel.SelectBuilder) (bool, error) {
+	var count int
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return false, err
+	}
+
+	err = tx.QueryRowContext(ctx, sql, args...).Scan(&count)
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func (r SyncRepository) getActivities(ctx context.Context, query squirrel.SelectBuilder) ([]entity.Activity, error) {
+	var data []entity.Activity
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return data, err
+	}
+
+	rows, err := r.Db.QueryContext(ctx, sql, args...)
+	if err != nil {
+		return data, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var activity entity.Activity
+		err := rows.Scan(
+			&activity.ID,
+			&activity.Title,
+			&activity.Type,
+			&activity.CreatedAt,
+			&activity.UpdatedAt,
+			&activity.DeletedAt,
+			&activity.ArchivedAt,
+		)
+		if err != nil {
+			return data, err
+		}
+
+		data = append(data, activity)
+	}
+
+	return data, rows.Err()
+}
+
+func (r SyncRepository) getTasks(ctx context.Context, query squirrel.SelectBuilder) ([]entity.Task, error) {
+	var data []entity.Task
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return data, err
+	}
+
+	rows, err := r.Db.QueryContext(ctx, sql, args...)
+	if err != nil {
+		return data, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var task entity.Task
+		err := rows.Scan(
+			&task.ID,
+			&task.Title,
+			&task.ActivityID,
+			&task.IsActive,
+			&task.Priority,
+			&task.Order,
+			&task.CreatedAt,
+			&task.UpdatedAt,
+			&task.DeletedAt,
+			&task.ArchivedAt,
+		)
+		if err != nil {
+			return data, err
+		}
+
+		data = append(data, task)
+	}
+
+	return data, rows.Err()
+}
+
+func (r SyncRepository) getTexts(ctx context.Context, query squirrel.SelectBuilder) ([]entity.Text, error) {
+	var data []entity.Text
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return data, err
+	}
+
+	rows, err := r.Db.QueryContext(ctx, sql, args...)
+	if err != nil {
+		return data, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var text entity.Text
+		err := rows.Scan(
+			&text.ID,
+			&text.Text,
+			&text.ActivityID,
+			&text.CreatedAt,
+			&text.UpdatedAt,
+			&text.DeletedAt,
+		)
+		if err != nil {
+			return data, err
+		}
+
+		data = append(data, text)
+	}
+
+	return data, rows.Err()
+}
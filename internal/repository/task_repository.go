@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/Masterminds/squirrel"
+	"github.com/digisata/todo-service/internal/constant"
 	"github.com/digisata/todo-service/internal/entity"
 	"github.com/digisata/todo-service/internal/shared"
 	"github.com/digisata/todo-service/pkg/postgres"
@@ -15,22 +16,26 @@ type TaskRepository struct {
 	*postgres.Postgres
 }
 
+const taskColumns = "id, title, activity_id, is_active, priority, order_position, created_at, updated_at, archived_at, due_at, ical_uid, caldav_name"
+
 func NewTask(db *postgres.Postgres) *TaskRepository {
 	return &TaskRepository{db}
 }
 
-func (r TaskRepository) Create(ctx context.Context, req entity.CreateTaskRequest) error {
+func (r TaskRepository) Create(ctx context.Context, req entity.CreateTaskRequest) (err error) {
+	defer r.ObserveQuery(ctx, "task", "Create", &err)()
+
 	now := time.Now().UTC()
 	sql, args, err := r.Builder.
 		Insert("tasks").
-		Columns("title, activity_id, is_active, priority, created_at, updated_at").
-		Values(req.Title, req.ActivityID, req.IsActive, req.Priority, now, now).
+		Columns("title, activity_id, is_active, priority, due_at, ical_uid, caldav_name, created_at, updated_at").
+		Values(req.Title, req.ActivityID, req.IsActive, req.Priority, req.DueAt, req.ICalUID, req.CalDAVName, now, now).
 		ToSql()
 	if err != nil {
 		return err
 	}
 
-	_, err = r.Db.ExecContext(ctx, sql, args...)
+	_, err = r.Executor(ctx).ExecContext(ctx, sql, args...)
 	if err != nil {
 		return err
 	}
@@ -38,7 +43,9 @@ func (r TaskRepository) Create(ctx context.Context, req entity.CreateTaskRequest
 	return nil
 }
 
-func (r TaskRepository) Update(ctx context.Context, req entity.UpdateTaskRequest) error {
+func (r TaskRepository) Update(ctx context.Context, req entity.UpdateTaskRequest) (err error) {
+	defer r.ObserveQuery(ctx, "task", "Update", &err)()
+
 	tx, err := r.Db.Begin()
 	if err != nil {
 		return err
@@ -55,6 +62,9 @@ func (r TaskRepository) Update(ctx context.Context, req entity.UpdateTaskRequest
 	}()
 
 	updateValue := shared.CreateUpdateValueMap(req)
+	if req.ClearDueAt {
+		updateValue["due_at"] = nil
+	}
 
 	sql, args, err := r.Builder.
 		Update("tasks").
@@ -83,14 +93,16 @@ func (r TaskRepository) Update(ctx context.Context, req entity.UpdateTaskRequest
 	return nil
 }
 
-func (r TaskRepository) GetAll(ctx context.Context, req entity.GetAllTaskRequest) ([]entity.Task, entity.Paging, error) {
+func (r TaskRepository) GetAll(ctx context.Context, req entity.GetAllTaskRequest) (_ []entity.Task, _ entity.Paging, err error) {
+	defer r.ObserveQuery(ctx, "task", "GetAll", &err)()
+
 	var (
 		data   []entity.Task
 		paging entity.Paging
 	)
 
 	baseQuery := r.Builder.
-		Select("id, title, activity_id, is_active, priority, order_position, created_at, updated_at").
+		Select(taskColumns).
 		From("tasks").
 		Where(squirrel.Eq{"activity_id": req.ActivityID}).
 		Where(squirrel.Eq{"deleted_at": nil})
@@ -104,6 +116,16 @@ func (r TaskRepository) GetAll(ctx context.Context, req entity.GetAllTaskRequest
 
 	var isFilterApplied bool
 
+	// Archived tasks are hidden unless explicitly queried
+	isArchived := req.IsArchived != nil && *req.IsArchived
+	if isArchived {
+		baseQuery = baseQuery.Where(squirrel.NotEq{"archived_at": nil})
+		countQuery = countQuery.Where(squirrel.NotEq{"archived_at": nil})
+	} else {
+		baseQuery = baseQuery.Where(squirrel.Eq{"archived_at": nil})
+		countQuery = countQuery.Where(squirrel.Eq{"archived_at": nil})
+	}
+
 	// Apply search filter if present
 	if req.Search != nil {
 		isFilterApplied = true
@@ -195,17 +217,7 @@ func (r TaskRepository) GetAll(ctx context.Context, req entity.GetAllTaskRequest
 	defer rows.Close()
 
 	for rows.Next() {
-		var task entity.Task
-		err := rows.Scan(
-			&task.ID,
-			&task.Title,
-			&task.ActivityID,
-			&task.IsActive,
-			&task.Priority,
-			&task.Order,
-			&task.CreatedAt,
-			&task.UpdatedAt,
-		)
+		task, err := scanTask(rows)
 		if err != nil {
 			return data, paging, err
 		}
@@ -216,38 +228,174 @@ func (r TaskRepository) GetAll(ctx context.Context, req entity.GetAllTaskRequest
 	return data, paging, nil
 }
 
-func (r TaskRepository) GetByID(ctx context.Context, id string) (entity.Task, error) {
+// GetAllDue lists the live tasks with a due date, grouped by activity and
+// soonest first within an activity.
+func (r TaskRepository) GetAllDue(ctx context.Context, req entity.GetAllDueTaskRequest) (_ []entity.Task, _ entity.Paging, err error) {
+	defer r.ObserveQuery(ctx, "task", "GetAllDue", &err)()
+
+	var (
+		data   []entity.Task
+		paging entity.Paging
+	)
+
+	baseQuery := r.Builder.
+		Select(taskColumns).
+		From("tasks").
+		Where(squirrel.NotEq{"due_at": nil}).
+		Where(squirrel.Eq{"archived_at": nil}).
+		Where(squirrel.Eq{"deleted_at": nil}).
+		OrderBy("activity_id ASC", "due_at ASC", "id ASC")
+
+	countQuery := r.Builder.
+		Select("COUNT(*)").
+		From("tasks").
+		Where(squirrel.NotEq{"due_at": nil}).
+		Where(squirrel.Eq{"archived_at": nil}).
+		Where(squirrel.Eq{"deleted_at": nil})
+
+	ownedBy := squirrel.Expr("activity_id IN (SELECT id FROM activities WHERE user_id = ?)", req.UserID)
+	baseQuery = baseQuery.Where(ownedBy)
+	countQuery = countQuery.Where(ownedBy)
+
+	if req.ActivityID != nil {
+		baseQuery = baseQuery.Where(squirrel.Eq{"activity_id": *req.ActivityID})
+		countQuery = countQuery.Where(squirrel.Eq{"activity_id": *req.ActivityID})
+	}
+
+	totalRowsSql, totalRowsArgs, err := countQuery.ToSql()
+	if err != nil {
+		return data, paging, err
+	}
+
+	var totalRows int32
+	err = r.Db.QueryRowContext(ctx, totalRowsSql, totalRowsArgs...).Scan(&totalRows)
+	if err != nil {
+		return data, paging, err
+	}
+
+	if req.Limit != nil && *req.Limit > 0 {
+		paging.TotalPage = (totalRows + *req.Limit - 1) / *req.Limit
+	} else {
+		paging.TotalPage = 1
+	}
+
+	if req.Page != nil && *req.Page > 0 {
+		paging.CurrentPage = *req.Page
+	} else {
+		paging.CurrentPage = 1
+	}
+
+	paging.Count = totalRows
+
+	if req.Page != nil && req.Limit != nil && *req.Limit > 0 {
+		offset := (*req.Page - 1) * *req.Limit
+		baseQuery = baseQuery.Limit(uint64(*req.Limit)).Offset(uint64(offset))
+	}
+
+	sql, args, err := baseQuery.ToSql()
+	if err != nil {
+		return data, paging, err
+	}
+
+	rows, err := r.Db.QueryContext(ctx, sql, args...)
+	if err != nil {
+		return data, paging, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return data, paging, err
+		}
+
+		data = append(data, task)
+	}
+
+	return data, paging, rows.Err()
+}
+
+// GetByCalDAVName finds a task by the resource name a CalDAV client gave it.
+// Tasks created elsewhere are named after their ID.
+func (r TaskRepository) GetByCalDAVName(ctx context.Context, activityID, name string) (_ entity.Task, err error) {
+	defer r.ObserveQuery(ctx, "task", "GetByCalDAVName", &err)()
+
 	var data entity.Task
 
 	sql, args, err := r.Builder.
-		Select("id, title, activity_id, is_active, priority, order_position, created_at, updated_at").
+		Select(taskColumns).
 		From("tasks").
-		Where(squirrel.Eq{"id": id}).
+		Where(squirrel.Eq{"activity_id": activityID}).
+		Where(squirrel.Or{
+			squirrel.Eq{"caldav_name": name},
+			squirrel.And{
+				squirrel.Eq{"caldav_name": nil},
+				squirrel.Expr("id::text || '.ics' = ?", name),
+			},
+		}).
 		Where(squirrel.Eq{"deleted_at": nil}).
 		ToSql()
 	if err != nil {
 		return data, err
 	}
 
-	rows := r.Db.QueryRowContext(ctx, sql, args...)
-	err = rows.Scan(
-		&data.ID,
-		&data.Title,
-		&data.ActivityID,
-		&data.IsActive,
-		&data.Priority,
-		&data.Order,
-		&data.CreatedAt,
-		&data.UpdatedAt,
-	)
+	return scanTask(r.Db.QueryRowContext(ctx, sql, args...))
+}
+
+// CountByActivity counts the tasks of an activity that are not deleted,
+// archived ones included. Like ActivityRepository.CountByUser it first takes
+// a transaction-level advisory lock on the activity, so inside a transaction
+// a concurrent count for the same activity waits until that transaction has
+// inserted or moved its tasks and ended.
+func (r TaskRepository) CountByActivity(ctx context.Context, activityID string) (_ int, err error) {
+	defer r.ObserveQuery(ctx, "task", "CountByActivity", &err)()
+
+	var count int
+
+	_, err = r.Executor(ctx).ExecContext(ctx, "SELECT pg_advisory_xact_lock(hashtext($1))", "tasks:"+activityID)
+	if err != nil {
+		return count, err
+	}
+
+	sql, args, err := r.Builder.
+		Select("COUNT(*)").
+		From("tasks").
+		Where(squirrel.Eq{"activity_id": activityID}).
+		Where(squirrel.Eq{"deleted_at": nil}).
+		ToSql()
+	if err != nil {
+		return count, err
+	}
+
+	err = r.Executor(ctx).QueryRowContext(ctx, sql, args...).Scan(&count)
+	if err != nil {
+		return count, err
+	}
+
+	return count, nil
+}
+
+func (r TaskRepository) GetByID(ctx context.Context, id string) (_ entity.Task, err error) {
+	defer r.ObserveQuery(ctx, "task", "GetByID", &err)()
+
+	var data entity.Task
+
+	sql, args, err := r.Builder.
+		Select(taskColumns).
+		From("tasks").
+		Where(squirrel.Eq{"id": id}).
+		Where(squirrel.Eq{"deleted_at": nil}).
+		ToSql()
 	if err != nil {
 		return data, err
 	}
 
-	return data, nil
+	return scanTask(r.Db.QueryRowContext(ctx, sql, args...))
 }
 
-func (r TaskRepository) Delete(ctx context.Context, id string) error {
+func (r TaskRepository) Delete(ctx context.Context, id string) (err error) {
+	defer r.ObserveQuery(ctx, "task", "Delete", &err)()
+
 	tx, err := r.Db.Begin()
 	if err != nil {
 		return err
@@ -293,3 +441,292 @@ func (r TaskRepository) Delete(ctx context.Context, id string) error {
 
 	return nil
 }
+
+// ArchiveCompleted archives every completed task of an activity and returns
+// how many tasks were archived.
+func (r TaskRepository) ArchiveCompleted(ctx context.Context, activityID string) (_ int64, err error) {
+	defer r.ObserveQuery(ctx, "task", "ArchiveCompleted", &err)()
+
+	now := time.Now().UTC()
+	archiveValue := map[string]interface{}{
+		"archived_at": now,
+		"updated_at":  now,
+	}
+
+	sql, args, err := r.Builder.
+		Update("tasks").
+		SetMap(archiveValue).
+		Where(squirrel.Eq{"activity_id": activityID}).
+		Where(squirrel.Eq{"is_active": false}).
+		Where(squirrel.Eq{"archived_at": nil}).
+		Where(squirrel.Eq{"deleted_at": nil}).
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := r.Db.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// BulkCreate inserts all tasks whose activity exists in a single statement.
+// It joins the transaction of ctx when there is one.
+func (r TaskRepository) BulkCreate(ctx context.Context, req []entity.CreateTaskRequest) (data []entity.BulkTaskResult, err error) {
+	defer r.ObserveQuery(ctx, "task", "BulkCreate", &err)()
+
+	err = r.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+		data, err = r.bulkCreate(ctx, r.Executor(ctx), req)
+
+		return err
+	})
+
+	return data, err
+}
+
+func (r TaskRepository) bulkCreate(ctx context.Context, tx postgres.Executor, req []entity.CreateTaskRequest) (data []entity.BulkTaskResult, err error) {
+	var activityIDs []string
+	for _, task := range req {
+		activityIDs = append(activityIDs, task.ActivityID)
+	}
+
+	sql, args, err := r.Builder.
+		Select("id").
+		From("activities").
+		Where(squirrel.Eq{"id": activityIDs}).
+		Where(squirrel.Eq{"deleted_at": nil}).
+		ToSql()
+	if err != nil {
+		return data, err
+	}
+
+	rows, err := tx.QueryContext(ctx, sql, args...)
+	if err != nil {
+		return data, err
+	}
+
+	activities := make(map[string]bool)
+	for rows.Next() {
+		var id string
+		if err = rows.Scan(&id); err != nil {
+			rows.Close()
+			return data, err
+		}
+		activities[id] = true
+	}
+	rows.Close()
+
+	now := time.Now().UTC()
+	data = make([]entity.BulkTaskResult, len(req))
+	insertQuery := r.Builder.
+		Insert("tasks").
+		Columns("title, activity_id, is_active, priority, due_at, created_at, updated_at")
+
+	var pending []int
+	for i, task := range req {
+		data[i] = entity.BulkTaskResult{Index: i}
+		if !activities[task.ActivityID] {
+			data[i].Status = constant.BULK_STATUS_NOT_FOUND
+			continue
+		}
+
+		isActive := task.IsActive == nil || *task.IsActive
+		insertQuery = insertQuery.Values(task.Title, task.ActivityID, isActive, task.Priority, task.DueAt, now, now)
+		pending = append(pending, i)
+	}
+
+	if len(pending) == 0 {
+		return data, nil
+	}
+
+	sql, args, err = insertQuery.Suffix("RETURNING id").ToSql()
+	if err != nil {
+		return data, err
+	}
+
+	rows, err = tx.QueryContext(ctx, sql, args...)
+	if err != nil {
+		return data, err
+	}
+	defer rows.Close()
+
+	for _, i := range pending {
+		if !rows.Next() {
+			break
+		}
+
+		if err = rows.Scan(&data[i].ID); err != nil {
+			return data, err
+		}
+		data[i].Status = constant.BULK_STATUS_SUCCESS
+	}
+
+	return data, rows.Err()
+}
+
+func (r TaskRepository) BulkDelete(ctx context.Context, req entity.BulkTaskRequest) (_ []entity.BulkTaskResult, err error) {
+	defer r.ObserveQuery(ctx, "task", "BulkDelete", &err)()
+
+	deleteValue := map[string]interface{}{
+		"deleted_at": time.Now().UTC(),
+	}
+
+	return r.bulkUpdate(ctx, req, "", deleteValue)
+}
+
+func (r TaskRepository) BulkComplete(ctx context.Context, req entity.BulkTaskRequest) (_ []entity.BulkTaskResult, err error) {
+	defer r.ObserveQuery(ctx, "task", "BulkComplete", &err)()
+
+	completeValue := map[string]interface{}{
+		"is_active":  false,
+		"updated_at": time.Now().UTC(),
+	}
+
+	return r.bulkUpdate(ctx, req, "", completeValue)
+}
+
+func (r TaskRepository) BulkMove(ctx context.Context, req entity.BulkMoveTaskRequest) (_ []entity.BulkTaskResult, err error) {
+	defer r.ObserveQuery(ctx, "task", "BulkMove", &err)()
+
+	moveValue := map[string]interface{}{
+		"activity_id": req.TargetActivityID,
+		"updated_at":  time.Now().UTC(),
+	}
+
+	return r.bulkUpdate(ctx, entity.BulkTaskRequest{ActivityID: req.ActivityID, IDs: req.IDs}, req.TargetActivityID, moveValue)
+}
+
+// bulkUpdate applies setValue to every live task of req.ActivityID in one
+// statement and reports a result for each requested ID. When targetActivityID
+// is set it must be a live activity, otherwise nothing is changed. It joins
+// the transaction of ctx when there is one.
+func (r TaskRepository) bulkUpdate(ctx context.Context, req entity.BulkTaskRequest, targetActivityID string, setValue map[string]interface{}) (data []entity.BulkTaskResult, err error) {
+	err = r.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+		data, err = r.bulkUpdateTx(ctx, r.Executor(ctx), req, targetActivityID, setValue)
+
+		return err
+	})
+
+	return data, err
+}
+
+func (r TaskRepository) bulkUpdateTx(ctx context.Context, tx postgres.Executor, req entity.BulkTaskRequest, targetActivityID string, setValue map[string]interface{}) (data []entity.BulkTaskResult, err error) {
+	if targetActivityID != "" {
+		var count int
+
+		sql, args, err := r.Builder.
+			Select("COUNT(*)").
+			From("activities").
+			Where(squirrel.Eq{"id": targetActivityID}).
+			Where(squirrel.Eq{"deleted_at": nil}).
+			ToSql()
+		if err != nil {
+			return data, err
+		}
+
+		err = tx.QueryRowContext(ctx, sql, args...).Scan(&count)
+		if err != nil {
+			return data, err
+		}
+
+		if count == 0 {
+			return data, fmt.Errorf("data not found")
+		}
+	}
+
+	sql, args, err := r.Builder.
+		Select("id, activity_id").
+		From("tasks").
+		Where(squirrel.Eq{"id": req.IDs}).
+		Where(squirrel.Eq{"deleted_at": nil}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return data, err
+	}
+
+	rows, err := tx.QueryContext(ctx, sql, args...)
+	if err != nil {
+		return data, err
+	}
+
+	owners := make(map[string]string)
+	for rows.Next() {
+		var id, activityID string
+		if err = rows.Scan(&id, &activityID); err != nil {
+			rows.Close()
+			return data, err
+		}
+		owners[id] = activityID
+	}
+	rows.Close()
+
+	var allowed []string
+	data = make([]entity.BulkTaskResult, len(req.IDs))
+	for i, id := range req.IDs {
+		data[i] = entity.BulkTaskResult{Index: i, ID: id}
+
+		activityID, ok := owners[id]
+		switch {
+		case !ok:
+			data[i].Status = constant.BULK_STATUS_NOT_FOUND
+		case activityID != req.ActivityID:
+			data[i].Status = constant.BULK_STATUS_PERMISSION_DENIED
+		default:
+			data[i].Status = constant.BULK_STATUS_SUCCESS
+			allowed = append(allowed, id)
+		}
+	}
+
+	if len(allowed) == 0 {
+		return data, nil
+	}
+
+	sql, args, err = r.Builder.
+		Update("tasks").
+		SetMap(setValue).
+		Where(squirrel.Eq{"id": allowed}).
+		ToSql()
+	if err != nil {
+		return data, err
+	}
+
+	_, err = tx.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return data, err
+	}
+
+	return data, nil
+}
+
+// rowScanner is either *sql.Row or *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanTask reads a row selected with taskColumns.
+func scanTask(row rowScanner) (entity.Task, error) {
+	var data entity.Task
+
+	err := row.Scan(
+		&data.ID,
+		&data.Title,
+		&data.ActivityID,
+		&data.IsActive,
+		&data.Priority,
+		&data.Order,
+		&data.CreatedAt,
+		&data.UpdatedAt,
+		&data.ArchivedAt,
+		&data.DueAt,
+		&data.ICalUID,
+		&data.CalDAVName,
+	)
+
+	return data, err
+}
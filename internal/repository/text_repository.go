@@ -19,18 +19,20 @@ func NewText(db *postgres.Postgres) *TextRepository {
 	return &TextRepository{db}
 }
 
-func (r TextRepository) Create(ctx context.Context, req entity.CreateTextRequest) error {
+func (r TextRepository) Create(ctx context.Context, req entity.CreateTextRequest) (err error) {
+	defer r.ObserveQuery(ctx, "text", "Create", &err)()
+
 	now := time.Now().UTC()
 	sql, args, err := r.Builder.
 		Insert("texts").
-		Columns("text, activity_id, created_at, updated_at").
-		Values(req.Text, req.ActivityID, now, now).
+		Columns("text, plain_text, activity_id, created_at, updated_at").
+		Values(req.Text, req.PlainText, req.ActivityID, now, now).
 		ToSql()
 	if err != nil {
 		return err
 	}
 
-	_, err = r.Db.ExecContext(ctx, sql, args...)
+	_, err = r.Executor(ctx).ExecContext(ctx, sql, args...)
 	if err != nil {
 		return err
 	}
@@ -38,7 +40,9 @@ func (r TextRepository) Create(ctx context.Context, req entity.CreateTextRequest
 	return nil
 }
 
-func (r TextRepository) Update(ctx context.Context, req entity.UpdateTextRequest) error {
+func (r TextRepository) Update(ctx context.Context, req entity.UpdateTextRequest) (err error) {
+	defer r.ObserveQuery(ctx, "text", "Update", &err)()
+
 	tx, err := r.Db.Begin()
 	if err != nil {
 		return err
@@ -82,14 +86,16 @@ func (r TextRepository) Update(ctx context.Context, req entity.UpdateTextRequest
 	return nil
 }
 
-func (r TextRepository) GetAll(ctx context.Context, req entity.GetAllTextRequest) ([]entity.Text, entity.Paging, error) {
+func (r TextRepository) GetAll(ctx context.Context, req entity.GetAllTextRequest) (_ []entity.Text, _ entity.Paging, err error) {
+	defer r.ObserveQuery(ctx, "text", "GetAll", &err)()
+
 	var (
 		data   []entity.Text
 		paging entity.Paging
 	)
 
 	baseQuery := r.Builder.
-		Select("id, text, activity_id, created_at, updated_at").
+		Select("id, text, plain_text, activity_id, created_at, updated_at").
 		From("texts").
 		Where(squirrel.Eq{"activity_id": req.ActivityID}).
 		Where(squirrel.Eq{"deleted_at": nil})
@@ -107,8 +113,8 @@ func (r TextRepository) GetAll(ctx context.Context, req entity.GetAllTextRequest
 	if req.Search != nil {
 		isFilterApplied = true
 		searchPattern := fmt.Sprintf("%%%s%%", *req.Search)
-		baseQuery = baseQuery.Where(squirrel.ILike{"title": searchPattern})
-		countQuery = countQuery.Where(squirrel.ILike{"title": searchPattern})
+		baseQuery = baseQuery.Where(squirrel.ILike{"plain_text": searchPattern})
+		countQuery = countQuery.Where(squirrel.ILike{"plain_text": searchPattern})
 	}
 
 	if req.IsNewest != nil && *req.IsNewest {
@@ -186,6 +192,7 @@ func (r TextRepository) GetAll(ctx context.Context, req entity.GetAllTextRequest
 		err := rows.Scan(
 			&task.ID,
 			&task.Text,
+			&task.PlainText,
 			&task.ActivityID,
 			&task.CreatedAt,
 			&task.UpdatedAt,
@@ -200,11 +207,13 @@ func (r TextRepository) GetAll(ctx context.Context, req entity.GetAllTextRequest
 	return data, paging, nil
 }
 
-func (r TextRepository) GetByID(ctx context.Context, id string) (entity.Text, error) {
+func (r TextRepository) GetByID(ctx context.Context, id string) (_ entity.Text, err error) {
+	defer r.ObserveQuery(ctx, "text", "GetByID", &err)()
+
 	var data entity.Text
 
 	sql, args, err := r.Builder.
-		Select("id, text, activity_id, created_at, updated_at").
+		Select("id, text, plain_text, activity_id, created_at, updated_at").
 		From("texts").
 		Where(squirrel.Eq{"id": id}).
 		Where(squirrel.Eq{"deleted_at": nil}).
@@ -216,8 +225,9 @@ func (r TextRepository) GetByID(ctx context.Context, id string) (entity.Text, er
 	rows := r.Db.QueryRowContext(ctx, sql, args...)
 	err = rows.Scan(
 		&data.ID,
-		&data.ActivityID,
 		&data.Text,
+		&data.PlainText,
+		&data.ActivityID,
 		&data.CreatedAt,
 		&data.UpdatedAt,
 	)
@@ -228,7 +238,9 @@ func (r TextRepository) GetByID(ctx context.Context, id string) (entity.Text, er
 	return data, nil
 }
 
-func (r TextRepository) Delete(ctx context.Context, id string) error {
+func (r TextRepository) Delete(ctx context.Context, id string) (err error) {
+	defer r.ObserveQuery(ctx, "text", "Delete", &err)()
+
 	tx, err := r.Db.Begin()
 	if err != nil {
 		return err
@@ -274,3 +286,309 @@ func (r TextRepository) Delete(ctx context.Context, id string) error {
 
 	return nil
 }
+
+func (r TextRepository) GetAllRevision(ctx context.Context, req entity.GetAllTextRevisionRequest) (_ []entity.TextRevision, _ entity.Paging, err error) {
+	defer r.ObserveQuery(ctx, "text", "GetAllRevision", &err)()
+
+	var (
+		data   []entity.TextRevision
+		paging entity.Paging
+	)
+
+	baseQuery := r.Builder.
+		Select("text_id, revision, text, plain_text, created_at").
+		From("text_revisions").
+		Where(squirrel.Eq{"text_id": req.TextID}).
+		OrderBy("revision DESC")
+
+	countQuery := r.Builder.
+		Select("COUNT(*)").
+		From("text_revisions").
+		Where(squirrel.Eq{"text_id": req.TextID})
+
+	totalRowsSql, totalRowsArgs, err := countQuery.ToSql()
+	if err != nil {
+		return data, paging, err
+	}
+
+	var totalRows int32
+	err = r.Db.QueryRowContext(ctx, totalRowsSql, totalRowsArgs...).Scan(&totalRows)
+	if err != nil {
+		return data, paging, err
+	}
+
+	if req.Limit != nil && *req.Limit > 0 {
+		paging.TotalPage = (totalRows + *req.Limit - 1) / *req.Limit
+	} else {
+		paging.TotalPage = 1
+	}
+
+	if req.Page != nil && *req.Page > 0 {
+		paging.CurrentPage = *req.Page
+	} else {
+		paging.CurrentPage = 1
+	}
+
+	paging.Count = totalRows
+
+	if req.Page != nil && req.Limit != nil && *req.Limit > 0 {
+		offset := (*req.Page - 1) * *req.Limit
+		baseQuery = baseQuery.Limit(uint64(*req.Limit)).Offset(uint64(offset))
+	}
+
+	sql, args, err := baseQuery.ToSql()
+	if err != nil {
+		return data, paging, err
+	}
+
+	rows, err := r.Db.QueryContext(ctx, sql, args...)
+	if err != nil {
+		return data, paging, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var revision entity.TextRevision
+		err := rows.Scan(
+			&revision.TextID,
+			&revision.Revision,
+			&revision.Text,
+			&revision.PlainText,
+			&revision.CreatedAt,
+		)
+		if err != nil {
+			return data, paging, err
+		}
+
+		data = append(data, revision)
+	}
+
+	return data, paging, rows.Err()
+}
+
+func (r TextRepository) GetRevision(ctx context.Context, req entity.GetTextRevisionRequest) (_ entity.TextRevision, err error) {
+	defer r.ObserveQuery(ctx, "text", "GetRevision", &err)()
+
+	var data entity.TextRevision
+
+	sql, args, err := r.Builder.
+		Select("text_id, revision, text, plain_text, created_at").
+		From("text_revisions").
+		Where(squirrel.Eq{"text_id": req.TextID}).
+		Where(squirrel.Eq{"revision": req.Revision}).
+		ToSql()
+	if err != nil {
+		return data, err
+	}
+
+	err = r.Db.QueryRowContext(ctx, sql, args...).Scan(
+		&data.TextID,
+		&data.Revision,
+		&data.Text,
+		&data.PlainText,
+		&data.CreatedAt,
+	)
+	if err != nil {
+		return data, err
+	}
+
+	return data, nil
+}
+
+// RestoreRevision copies a revision back into the note. The revision trigger
+// is forced to snapshot the current content first so a restore can be undone.
+func (r TextRepository) RestoreRevision(ctx context.Context, req entity.GetTextRevisionRequest) (err error) {
+	defer r.ObserveQuery(ctx, "text", "RestoreRevision", &err)()
+
+	tx, err := r.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		} else if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	_, err = tx.ExecContext(ctx, "SET LOCAL todo.force_text_revision = 'on'")
+	if err != nil {
+		return err
+	}
+
+	sql, args, err := r.Builder.
+		Update("texts").
+		Set("text", squirrel.Expr("r.text")).
+		Set("plain_text", squirrel.Expr("r.plain_text")).
+		Set("updated_at", time.Now().UTC()).
+		From("text_revisions r").
+		Where("r.text_id = texts.id").
+		Where(squirrel.Eq{"texts.id": req.TextID}).
+		Where(squirrel.Eq{"r.revision": req.Revision}).
+		Where(squirrel.Eq{"texts.deleted_at": nil}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	res, err := tx.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("data not found")
+	}
+
+	return nil
+}
+
+// GetUnsanitized returns notes stored before the sanitizer was added. Only
+// their ID and text are set.
+func (r TextRepository) GetUnsanitized(ctx context.Context, limit int) (_ []entity.Text, err error) {
+	defer r.ObserveQuery(ctx, "text", "GetUnsanitized", &err)()
+
+	var data []entity.Text
+
+	sql, args, err := r.Builder.
+		Select("id, text").
+		From("texts").
+		Where("NOT sanitized").
+		OrderBy("id").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return data, err
+	}
+
+	rows, err := r.Db.QueryContext(ctx, sql, args...)
+	if err != nil {
+		return data, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var text entity.Text
+		err := rows.Scan(&text.ID, &text.Text)
+		if err != nil {
+			return data, err
+		}
+
+		data = append(data, text)
+	}
+
+	return data, rows.Err()
+}
+
+// UpdateSanitized stores the re-rendered content of a note without taking a
+// revision of the unsanitized one or touching updated_at.
+func (r TextRepository) UpdateSanitized(ctx context.Context, req entity.Text) (err error) {
+	defer r.ObserveQuery(ctx, "text", "UpdateSanitized", &err)()
+
+	tx, err := r.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		} else if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	_, err = tx.ExecContext(ctx, "SET LOCAL todo.skip_text_revision = 'on'")
+	if err != nil {
+		return err
+	}
+
+	sql, args, err := r.Builder.
+		Update("texts").
+		Set("text", req.Text).
+		Set("plain_text", req.PlainText).
+		Set("sanitized", true).
+		Where(squirrel.Eq{"id": req.ID}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetUnsanitizedRevisions is GetUnsanitized for revisions. Only their text
+// ID, revision and text are set.
+func (r TextRepository) GetUnsanitizedRevisions(ctx context.Context, limit int) (_ []entity.TextRevision, err error) {
+	defer r.ObserveQuery(ctx, "text", "GetUnsanitizedRevisions", &err)()
+
+	var data []entity.TextRevision
+
+	sql, args, err := r.Builder.
+		Select("text_id, revision, text").
+		From("text_revisions").
+		Where("NOT sanitized").
+		OrderBy("text_id, revision").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return data, err
+	}
+
+	rows, err := r.Db.QueryContext(ctx, sql, args...)
+	if err != nil {
+		return data, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var revision entity.TextRevision
+		err := rows.Scan(&revision.TextID, &revision.Revision, &revision.Text)
+		if err != nil {
+			return data, err
+		}
+
+		data = append(data, revision)
+	}
+
+	return data, rows.Err()
+}
+
+func (r TextRepository) UpdateSanitizedRevision(ctx context.Context, req entity.TextRevision) (err error) {
+	defer r.ObserveQuery(ctx, "text", "UpdateSanitizedRevision", &err)()
+
+	sql, args, err := r.Builder.
+		Update("text_revisions").
+		Set("text", req.Text).
+		Set("plain_text", req.PlainText).
+		Set("sanitized", true).
+		Where(squirrel.Eq{"text_id": req.TextID}).
+		Where(squirrel.Eq{"revision": req.Revision}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.Db.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package shared
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/digisata/todo-service/internal/constant"
+	"github.com/digisata/todo-service/internal/entity"
+)
+
+// activityTypes is the registry of supported activity types. Kind mirrors the
+// ActivityType enum in the activity proto and every name must also exist in
+// the activity_types table.
+var activityTypes = []entity.ActivityType{
+	{
+		Name:        constant.ACTIVITY_TYPE_TASK,
+		Kind:        1,
+		Description: "List of tasks",
+		AllowTasks:  true,
+	},
+	{
+		Name:         constant.ACTIVITY_TYPE_TEXT,
+		Kind:         2,
+		Description:  "Single rich-text note",
+		AllowTexts:   true,
+		DefaultTexts: []string{`<p class="default-text">Fill your note ....</p>`},
+	},
+	{
+		Name:        constant.ACTIVITY_TYPE_CHECKLIST,
+		Kind:        3,
+		Description: "Checklist of short items",
+		AllowTasks:  true,
+	},
+	{
+		Name:        constant.ACTIVITY_TYPE_KANBAN,
+		Kind:        4,
+		Description: "Board of prioritised tasks",
+		AllowTasks:  true,
+	},
+	{
+		Name:        constant.ACTIVITY_TYPE_JOURNAL,
+		Kind:        5,
+		Description: "Series of dated notes",
+		AllowTexts:  true,
+	},
+}
+
+// GetActivityType returns the registered type with the given name. An empty
+// name resolves to the task list type for clients that never sent a type.
+func GetActivityType(name string) (entity.ActivityType, bool) {
+	if name == "" {
+		name = constant.ACTIVITY_TYPE_TASK
+	}
+
+	for _, activityType := range activityTypes {
+		if activityType.Name == name {
+			return activityType, true
+		}
+	}
+
+	return entity.ActivityType{}, false
+}
+
+func GetActivityTypeByKind(kind int32) (entity.ActivityType, bool) {
+	for _, activityType := range activityTypes {
+		if activityType.Kind == kind {
+			return activityType, true
+		}
+	}
+
+	return entity.ActivityType{}, false
+}
+
+func GetAllActivityTypes() []entity.ActivityType {
+	return activityTypes
+}
+
+// ValidateActivityTitle checks the title of an activity. Every type shares the
+// same rule, the size of the title column.
+func ValidateActivityTitle(title string) error {
+	if title == "" {
+		return fmt.Errorf("%w: title is required", entity.ErrInvalidActivity)
+	}
+
+	if utf8.RuneCountInString(title) > constant.MAX_TITLE_LENGTH {
+		return fmt.Errorf("%w: title must be at most %d characters", entity.ErrInvalidActivity, constant.MAX_TITLE_LENGTH)
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package shared
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/digisata/todo-service/internal/entity"
+)
+
+// EncodeCursor turns a keyset position into an opaque token for clients.
+func EncodeCursor(cursor entity.KeysetCursor) string {
+	raw := strings.Join([]string{
+		strconv.FormatInt(cursor.CreatedAt.UnixNano(), 10),
+		cursor.Kind,
+		cursor.ID,
+	}, "|")
+
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func DecodeCursor(token string) (entity.KeysetCursor, error) {
+	var cursor entity.KeysetCursor
+
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return cursor, fmt.Errorf("%w: %v", entity.ErrInvalidCursor, err)
+	}
+
+	parts := strings.Split(string(raw), "|")
+	if len(parts) != 3 {
+		return cursor, entity.ErrInvalidCursor
+	}
+
+	nanos, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return cursor, fmt.Errorf("%w: %v", entity.ErrInvalidCursor, err)
+	}
+
+	cursor.CreatedAt = time.Unix(0, nanos).UTC()
+	cursor.Kind = parts[1]
+	cursor.ID = parts[2]
+
+	return cursor, nil
+}
+
+// EncodeSnapshotCursor turns a snapshot position into an opaque token for
+// clients.
+func EncodeSnapshotCursor(cursor entity.SnapshotCursor) string {
+	raw := strconv.FormatInt(cursor.Token, 10) + "|" + cursor.ActivityID
+
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func DecodeSnapshotCursor(token string) (entity.SnapshotCursor, error) {
+	var cursor entity.SnapshotCursor
+
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return cursor, fmt.Errorf("%w: %v", entity.ErrInvalidCursor, err)
+	}
+
+	parts := strings.Split(string(raw), "|")
+	if len(parts) != 2 {
+		return cursor, entity.ErrInvalidCursor
+	}
+
+	cursor.Token, err = strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return cursor, fmt.Errorf("%w: %v", entity.ErrInvalidCursor, err)
+	}
+
+	cursor.ActivityID = parts[1]
+
+	return cursor, nil
+}
+
+// CursorLimit clamps a requested page size.
+func CursorLimit(limit *int32, def, max int32) int32 {
+	if limit == nil || *limit <= 0 {
+		return def
+	}
+
+	if *limit > max {
+		return max
+	}
+
+	return *limit
+}
@@ -2,15 +2,52 @@ package shared
 
 import (
 	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/digisata/todo-service/internal/entity"
 )
 
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
 func ConvertToJakartaTime(t time.Time) time.Time {
 	return t.Add(7 * time.Hour)
 }
 
+func ConvertToJakartaTimePtr(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+
+	converted := ConvertToJakartaTime(*t)
+
+	return &converted
+}
+
+func ConvertFromJakartaTime(t time.Time) time.Time {
+	return t.Add(-7 * time.Hour)
+}
+
+func IsValidUUID(id string) bool {
+	return uuidPattern.MatchString(id)
+}
+
+// TaskETag identifies the current version of a task for CalDAV clients.
+func TaskETag(task entity.Task) string {
+	return strconv.FormatInt(task.UpdatedAt.UnixNano(), 36)
+}
+
+func Deref[T any](v *T) T {
+	var zero T
+	if v == nil {
+		return zero
+	}
+
+	return *v
+}
+
 func CreateUpdateValueMap[T entity.UpdateTaskRequest | entity.UpdateActivityRequest | entity.UpdateTextRequest](req T) map[string]interface{} {
 	updateValue := map[string]interface{}{
 		"updated_at": time.Now().UTC(),
@@ -45,3 +82,14 @@ func CreateUpdateValueMap[T entity.UpdateTaskRequest | entity.UpdateActivityRequ
 
 	return updateValue
 }
+
+// TruncateTitle cuts a title to max characters so long titles from other
+// apps and calendars still fit the title columns.
+func TruncateTitle(title string, max int) string {
+	runes := []rune(title)
+	if len(runes) <= max {
+		return title
+	}
+
+	return strings.TrimSpace(string(runes[:max-1])) + "…"
+}
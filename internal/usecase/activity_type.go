@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/digisata/todo-service/internal/constant"
+	"github.com/digisata/todo-service/internal/entity"
+	"github.com/digisata/todo-service/internal/shared"
+)
+
+// resolveActivityType looks up a registered type by kind, falling back to the
+// name for clients that still send the type as a string.
+func resolveActivityType(name string, kind int32) (entity.ActivityType, error) {
+	var (
+		activityType entity.ActivityType
+		ok           bool
+	)
+
+	if kind != 0 {
+		activityType, ok = shared.GetActivityTypeByKind(kind)
+	} else {
+		activityType, ok = shared.GetActivityType(name)
+	}
+
+	if !ok {
+		return activityType, entity.ErrInvalidActivityType
+	}
+
+	return activityType, nil
+}
+
+func activityKind(name string) int32 {
+	activityType, _ := shared.GetActivityType(name)
+
+	return activityType.Kind
+}
+
+// checkActivityChild makes sure the activity exists and its type accepts
+// children of the given kind.
+func checkActivityChild(ctx context.Context, activityRepository ActivityRepository, activityID, child string) error {
+	activity, err := activityRepository.GetByID(ctx, activityID)
+	if err != nil {
+		return err
+	}
+
+	return checkActivityTypeChild(activity.Type, child)
+}
+
+// checkActivityChildren fails when the activity has children the type does
+// not accept, so changing its type cannot strand them.
+func checkActivityChildren(ctx context.Context, activityRepository ActivityRepository, activityID, typeName string) error {
+	for _, child := range []string{constant.ENTITY_TASK, constant.ENTITY_TEXT} {
+		err := checkActivityTypeChild(typeName, child)
+		if !errors.Is(err, entity.ErrChildNotAllowed) {
+			continue
+		}
+
+		count, countErr := activityRepository.CountChildren(ctx, activityID, child)
+		if countErr != nil {
+			return countErr
+		}
+
+		if count > 0 {
+			return fmt.Errorf("%w: the activity still has %d %ss", err, count, child)
+		}
+	}
+
+	return nil
+}
+
+func checkActivityTypeChild(name, child string) error {
+	activityType, ok := shared.GetActivityType(name)
+	if !ok {
+		return entity.ErrInvalidActivityType
+	}
+
+	allowed := activityType.AllowTasks
+	if child == constant.ENTITY_TEXT {
+		allowed = activityType.AllowTexts
+	}
+
+	if !allowed {
+		return fmt.Errorf("%w: %s does not accept %ss", entity.ErrChildNotAllowed, activityType.Name, child)
+	}
+
+	return nil
+}
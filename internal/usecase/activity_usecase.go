@@ -2,29 +2,105 @@ package usecase
 
 import (
 	"context"
+	"time"
 
+	"github.com/digisata/todo-service/internal/constant"
 	"github.com/digisata/todo-service/internal/entity"
 	"github.com/digisata/todo-service/internal/shared"
 )
 
+const (
+	watchBatchSize int32 = 100
+	// watchRetryInterval is how often a watch looks again while events are
+	// held back by an older transaction, which sends no notification when it
+	// ends.
+	watchRetryInterval = 250 * time.Millisecond
+)
+
 type ActivityUseCase struct {
 	activityRepository ActivityRepository
+	eventRepository    EventRepository
+	eventNotifier      EventNotifier
+	transactor         Transactor
+	quota              entity.Quota
 }
 
-func NewActivity(activityRepository ActivityRepository) *ActivityUseCase {
-	return &ActivityUseCase{activityRepository: activityRepository}
+func NewActivity(activityRepository ActivityRepository, eventRepository EventRepository, eventNotifier EventNotifier, transactor Transactor, quota entity.Quota) *ActivityUseCase {
+	return &ActivityUseCase{
+		activityRepository: activityRepository,
+		eventRepository:    eventRepository,
+		eventNotifier:      eventNotifier,
+		transactor:         transactor,
+		quota:              quota,
+	}
 }
 
 func (u ActivityUseCase) CreateActivity(ctx context.Context, req entity.CreateActivityRequest) error {
-	err := u.activityRepository.Create(ctx, req)
+	ctx, span := tracer.Start(ctx, "ActivityUseCase.CreateActivity")
+	defer span.End()
+
+	activityType, err := resolveActivityType(req.Type, req.Kind)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	err = shared.ValidateActivityTitle(req.Title)
+	if err != nil {
+		return err
+	}
+
+	req.UserID = requestUserID(ctx)
+	req.Type = activityType.Name
+	req.DefaultTasks = activityType.DefaultTasks
+	for _, text := range activityType.DefaultTexts {
+		content, plainText, err := renderText(text, constant.TEXT_FORMAT_HTML)
+		if err != nil {
+			return err
+		}
+
+		req.DefaultTexts = append(req.DefaultTexts, entity.CreateTextRequest{
+			Text:      content,
+			PlainText: plainText,
+		})
+	}
+
+	return u.transactor.WithTx(ctx, func(ctx context.Context) error {
+		err := checkActivityQuota(ctx, u.activityRepository, u.quota, 1)
+		if err != nil {
+			return err
+		}
+
+		_, err = u.activityRepository.Create(ctx, req)
+
+		return err
+	})
 }
 
 func (u ActivityUseCase) UpdateActivity(ctx context.Context, req entity.UpdateActivityRequest) error {
+	ctx, span := tracer.Start(ctx, "ActivityUseCase.UpdateActivity")
+	defer span.End()
+
+	if req.Type != "" || req.Kind != 0 {
+		activityType, err := resolveActivityType(req.Type, req.Kind)
+		if err != nil {
+			return err
+		}
+
+		req.Type = activityType.Name
+
+		err = checkActivityChildren(ctx, u.activityRepository, req.ID, activityType.Name)
+		if err != nil {
+			return err
+		}
+	}
+
+	if req.Title != "" {
+		err := shared.ValidateActivityTitle(req.Title)
+		if err != nil {
+			return err
+		}
+	}
+
 	err := u.activityRepository.Update(ctx, req)
 	if err != nil {
 		return err
@@ -34,19 +110,27 @@ func (u ActivityUseCase) UpdateActivity(ctx context.Context, req entity.UpdateAc
 }
 
 func (u ActivityUseCase) GetActivity(ctx context.Context, id string) (entity.Activity, error) {
+	ctx, span := tracer.Start(ctx, "ActivityUseCase.GetActivity")
+	defer span.End()
+
 	var res entity.Activity
 	res, err := u.activityRepository.GetByID(ctx, id)
 	if err != nil {
 		return res, err
 	}
 
+	res.Kind = activityKind(res.Type)
 	res.CreatedAt = shared.ConvertToJakartaTime(res.CreatedAt)
 	res.UpdatedAt = shared.ConvertToJakartaTime(res.UpdatedAt)
+	res.ArchivedAt = shared.ConvertToJakartaTimePtr(res.ArchivedAt)
 
 	return res, nil
 }
 
 func (u ActivityUseCase) GetAllActivity(ctx context.Context, req entity.GetAllActivityRequest) ([]entity.Activity, entity.Paging, error) {
+	ctx, span := tracer.Start(ctx, "ActivityUseCase.GetAllActivity")
+	defer span.End()
+
 	var (
 		res    []entity.Activity
 		paging entity.Paging
@@ -57,14 +141,19 @@ func (u ActivityUseCase) GetAllActivity(ctx context.Context, req entity.GetAllAc
 	}
 
 	for i := 0; i < len(res); i++ {
+		res[i].Kind = activityKind(res[i].Type)
 		res[i].CreatedAt = shared.ConvertToJakartaTime(res[i].CreatedAt)
 		res[i].UpdatedAt = shared.ConvertToJakartaTime(res[i].UpdatedAt)
+		res[i].ArchivedAt = shared.ConvertToJakartaTimePtr(res[i].ArchivedAt)
 	}
 
 	return res, paging, nil
 }
 
 func (u ActivityUseCase) DeleteActivity(ctx context.Context, id string) error {
+	ctx, span := tracer.Start(ctx, "ActivityUseCase.DeleteActivity")
+	defer span.End()
+
 	err := u.activityRepository.Delete(ctx, id)
 	if err != nil {
 		return err
@@ -72,3 +161,98 @@ func (u ActivityUseCase) DeleteActivity(ctx context.Context, id string) error {
 
 	return nil
 }
+
+func (u ActivityUseCase) GetAllActivityType(ctx context.Context) []entity.ActivityType {
+	ctx, span := tracer.Start(ctx, "ActivityUseCase.GetAllActivityType")
+	defer span.End()
+
+	return shared.GetAllActivityTypes()
+}
+
+func (u ActivityUseCase) ArchiveActivity(ctx context.Context, id string) error {
+	ctx, span := tracer.Start(ctx, "ActivityUseCase.ArchiveActivity")
+	defer span.End()
+
+	err := u.activityRepository.Archive(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (u ActivityUseCase) UnarchiveActivity(ctx context.Context, id string) error {
+	ctx, span := tracer.Start(ctx, "ActivityUseCase.UnarchiveActivity")
+	defer span.End()
+
+	err := u.activityRepository.Unarchive(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// WatchActivity streams the task and text events of an activity until ctx is
+// done. Events after req.Cursor are replayed first; without a cursor only
+// events that happen after the call are sent.
+func (u ActivityUseCase) WatchActivity(ctx context.Context, req entity.WatchActivityRequest, send func(entity.Event) error) error {
+	ctx, span := tracer.Start(ctx, "ActivityUseCase.WatchActivity")
+	defer span.End()
+
+	_, err := u.activityRepository.GetByID(ctx, req.ActivityID)
+	if err != nil {
+		return err
+	}
+
+	// Subscribe before reading the cursor so no notification is missed in between
+	wake, unsubscribe := u.eventNotifier.Subscribe(req.ActivityID)
+	defer unsubscribe()
+
+	var cursor entity.EventCursor
+	if req.Cursor != nil {
+		cursor = *req.Cursor
+	} else {
+		cursor, err = u.eventRepository.GetCurrentCursor(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	for {
+		events, heldBack, err := u.eventRepository.GetAll(ctx, entity.GetAllEventRequest{
+			ActivityID: req.ActivityID,
+			Cursor:     cursor,
+			Limit:      watchBatchSize,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, event := range events {
+			event.CreatedAt = shared.ConvertToJakartaTime(event.CreatedAt)
+			err = send(event)
+			if err != nil {
+				return err
+			}
+
+			cursor = entity.EventCursor{XactID: event.XactID, ID: event.ID}
+		}
+
+		if int32(len(events)) == watchBatchSize {
+			continue
+		}
+
+		var retry <-chan time.Time
+		if heldBack {
+			retry = time.After(watchRetryInterval)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-wake:
+		case <-retry:
+		}
+	}
+}
@@ -0,0 +1,234 @@
+package usecase
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/digisata/todo-service/internal/constant"
+	"github.com/digisata/todo-service/internal/entity"
+	"github.com/digisata/todo-service/internal/shared"
+	"github.com/digisata/todo-service/pkg/blobstore"
+	"github.com/google/uuid"
+)
+
+// sniffLen is how much of a file http.DetectContentType looks at.
+const sniffLen = 512
+
+type AttachmentUseCase struct {
+	attachmentRepository AttachmentRepository
+	taskRepository       TaskRepository
+	textRepository       TextRepository
+	blobStore            BlobStore
+	maxSize              int64
+}
+
+func NewAttachment(attachmentRepository AttachmentRepository, taskRepository TaskRepository, textRepository TextRepository, blobStore BlobStore, maxSize int64) *AttachmentUseCase {
+	if maxSize <= 0 {
+		maxSize = constant.MAX_ATTACHMENT_SIZE
+	}
+
+	return &AttachmentUseCase{
+		attachmentRepository: attachmentRepository,
+		taskRepository:       taskRepository,
+		textRepository:       textRepository,
+		blobStore:            blobStore,
+		maxSize:              maxSize,
+	}
+}
+
+// UploadAttachment streams r into the blob store while measuring, hashing and
+// sniffing it. The metadata row is only written once the stored file matches
+// what the client announced.
+func (u AttachmentUseCase) UploadAttachment(ctx context.Context, req entity.CreateAttachmentRequest, r io.Reader) (entity.Attachment, error) {
+	ctx, span := tracer.Start(ctx, "AttachmentUseCase.UploadAttachment")
+	defer span.End()
+
+	var res entity.Attachment
+
+	fileName := filepath.Base(strings.ReplaceAll(req.FileName, "\\", "/"))
+	if fileName == "." || fileName == "/" || len(fileName) > 255 {
+		return res, fmt.Errorf("%w: invalid file name", entity.ErrInvalidAttachment)
+	}
+
+	if req.Size != nil && *req.Size > u.maxSize {
+		return res, fmt.Errorf("%w: limit is %d bytes", entity.ErrAttachmentTooLarge, u.maxSize)
+	}
+
+	err := u.checkAttachmentEntity(ctx, req.EntityType, req.EntityID)
+	if err != nil {
+		return res, err
+	}
+
+	br := bufio.NewReaderSize(r, sniffLen)
+	head, err := br.Peek(sniffLen)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, bufio.ErrBufferFull) {
+		return res, err
+	}
+
+	checksum := sha256.New()
+	body := &limitedReader{r: io.TeeReader(br, checksum), remaining: u.maxSize}
+
+	res = entity.Attachment{
+		ID:          uuid.NewString(),
+		EntityType:  req.EntityType,
+		EntityID:    req.EntityID,
+		FileName:    fileName,
+		ContentType: http.DetectContentType(head),
+	}
+	res.StorageKey = res.ID
+
+	err = u.blobStore.Put(ctx, res.StorageKey, body, -1, res.ContentType)
+	if body.exceeded {
+		u.blobStore.Delete(context.WithoutCancel(ctx), res.StorageKey)
+		return res, fmt.Errorf("%w: limit is %d bytes", entity.ErrAttachmentTooLarge, u.maxSize)
+	}
+
+	if err != nil {
+		u.blobStore.Delete(context.WithoutCancel(ctx), res.StorageKey)
+		return res, err
+	}
+
+	res.Size = u.maxSize - body.remaining
+	res.Checksum = hex.EncodeToString(checksum.Sum(nil))
+
+	if req.Size != nil && *req.Size != res.Size {
+		err = fmt.Errorf("%w: received %d bytes, expected %d", entity.ErrInvalidAttachment, res.Size, *req.Size)
+	} else if req.Checksum != nil && !strings.EqualFold(*req.Checksum, res.Checksum) {
+		err = fmt.Errorf("%w: received sha256 %s", entity.ErrChecksumMismatch, res.Checksum)
+	} else {
+		err = u.attachmentRepository.Create(ctx, res)
+	}
+
+	if err != nil {
+		u.blobStore.Delete(context.WithoutCancel(ctx), res.StorageKey)
+		return res, err
+	}
+
+	return res, nil
+}
+
+// DownloadAttachment opens the stored file. The returned reader fails with
+// entity.ErrChecksumMismatch at the end when the content was corrupted.
+func (u AttachmentUseCase) DownloadAttachment(ctx context.Context, id string) (entity.Attachment, io.ReadCloser, error) {
+	ctx, span := tracer.Start(ctx, "AttachmentUseCase.DownloadAttachment")
+	defer span.End()
+
+	res, err := u.attachmentRepository.GetByID(ctx, id)
+	if err != nil {
+		return res, nil, err
+	}
+
+	blob, err := u.blobStore.Get(ctx, res.StorageKey)
+	if errors.Is(err, blobstore.ErrNotFound) {
+		return res, nil, fmt.Errorf("data not found")
+	}
+
+	if err != nil {
+		return res, nil, err
+	}
+
+	res.CreatedAt = shared.ConvertToJakartaTime(res.CreatedAt)
+	res.UpdatedAt = shared.ConvertToJakartaTime(res.UpdatedAt)
+
+	return res, &verifyingReader{ReadCloser: blob, hash: sha256.New(), checksum: res.Checksum}, nil
+}
+
+func (u AttachmentUseCase) GetAllAttachment(ctx context.Context, req entity.GetAllAttachmentRequest) ([]entity.Attachment, error) {
+	ctx, span := tracer.Start(ctx, "AttachmentUseCase.GetAllAttachment")
+	defer span.End()
+
+	res, err := u.attachmentRepository.GetAll(ctx, req)
+	if err != nil {
+		return res, err
+	}
+
+	for i := 0; i < len(res); i++ {
+		res[i].CreatedAt = shared.ConvertToJakartaTime(res[i].CreatedAt)
+		res[i].UpdatedAt = shared.ConvertToJakartaTime(res[i].UpdatedAt)
+	}
+
+	return res, nil
+}
+
+func (u AttachmentUseCase) DeleteAttachment(ctx context.Context, id string) error {
+	ctx, span := tracer.Start(ctx, "AttachmentUseCase.DeleteAttachment")
+	defer span.End()
+
+	attachment, err := u.attachmentRepository.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	err = u.attachmentRepository.Delete(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return u.blobStore.Delete(ctx, attachment.StorageKey)
+}
+
+func (u AttachmentUseCase) checkAttachmentEntity(ctx context.Context, entityType, entityID string) error {
+	var err error
+
+	switch entityType {
+	case constant.ENTITY_TASK:
+		_, err = u.taskRepository.GetByID(ctx, entityID)
+	case constant.ENTITY_TEXT:
+		_, err = u.textRepository.GetByID(ctx, entityID)
+	default:
+		return fmt.Errorf("%w: entity_type must be %q or %q", entity.ErrInvalidAttachment, constant.ENTITY_TASK, constant.ENTITY_TEXT)
+	}
+
+	return err
+}
+
+// limitedReader fails once more than remaining bytes are read, unlike
+// io.LimitedReader which silently truncates.
+type limitedReader struct {
+	r         io.Reader
+	remaining int64
+	exceeded  bool
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if int64(len(p)) > l.remaining+1 {
+		p = p[:l.remaining+1]
+	}
+
+	n, err := l.r.Read(p)
+	if int64(n) > l.remaining {
+		l.exceeded = true
+		l.remaining = 0
+		return 0, entity.ErrAttachmentTooLarge
+	}
+
+	l.remaining -= int64(n)
+
+	return n, err
+}
+
+type verifyingReader struct {
+	io.ReadCloser
+	hash     hash.Hash
+	checksum string
+}
+
+func (v *verifyingReader) Read(p []byte) (int, error) {
+	n, err := v.ReadCloser.Read(p)
+	v.hash.Write(p[:n])
+
+	if errors.Is(err, io.EOF) && hex.EncodeToString(v.hash.Sum(nil)) != v.checksum {
+		return n, entity.ErrChecksumMismatch
+	}
+
+	return n, err
+}
@@ -0,0 +1,489 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/digisata/todo-service/internal/constant"
+	"github.com/digisata/todo-service/internal/entity"
+	"github.com/digisata/todo-service/internal/shared"
+	"github.com/digisata/todo-service/pkg/exporter"
+	"github.com/digisata/todo-service/pkg/identity"
+)
+
+// CalendarUseCase serves tasks to calendar apps. A feed token is the only
+// credential: it reads the .ics feed and signs in to CalDAV, and it sees
+// every activity of its user unless it was created for one.
+type CalendarUseCase struct {
+	feedTokenRepository FeedTokenRepository
+	activityRepository  ActivityRepository
+	taskRepository      TaskRepository
+	transactor          Transactor
+	publicURL           string
+	quota               entity.Quota
+}
+
+func NewCalendar(feedTokenRepository FeedTokenRepository, activityRepository ActivityRepository, taskRepository TaskRepository, transactor Transactor, publicURL string, quota entity.Quota) *CalendarUseCase {
+	return &CalendarUseCase{
+		feedTokenRepository: feedTokenRepository,
+		activityRepository:  activityRepository,
+		taskRepository:      taskRepository,
+		transactor:          transactor,
+		publicURL:           strings.TrimSuffix(publicURL, "/"),
+		quota:               quota,
+	}
+}
+
+// CreateFeedToken issues a token. Only its hash is stored, so the token and
+// the URLs holding it are returned this once.
+func (u CalendarUseCase) CreateFeedToken(ctx context.Context, req entity.CreateFeedTokenRequest) (entity.CreatedFeedToken, error) {
+	ctx, span := tracer.Start(ctx, "CalendarUseCase.CreateFeedToken")
+	defer span.End()
+
+	var res entity.CreatedFeedToken
+
+	if strings.TrimSpace(req.UserID) == "" {
+		return res, fmt.Errorf("%w: user_id is required", entity.ErrInvalidFeedToken)
+	}
+
+	err := checkCaller(ctx, req.UserID)
+	if err != nil {
+		return res, err
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		name = constant.DEFAULT_FEED_TOKEN_NAME
+	}
+
+	if utf8.RuneCountInString(name) > constant.MAX_FEED_TOKEN_NAME_LENGTH {
+		return res, fmt.Errorf("%w: name is longer than %d characters", entity.ErrInvalidFeedToken, constant.MAX_FEED_TOKEN_NAME_LENGTH)
+	}
+
+	if req.ActivityID != nil {
+		activity, err := u.activityRepository.GetByID(ctx, *req.ActivityID)
+		if err != nil {
+			return res, err
+		}
+
+		// Activities of other users are reported as missing.
+		if !isActivityOwner(activity, req.UserID) {
+			return res, sql.ErrNoRows
+		}
+
+		err = checkActivityTypeChild(activity.Type, constant.ENTITY_TASK)
+		if err != nil {
+			return res, err
+		}
+	}
+
+	secret := make([]byte, constant.FEED_TOKEN_BYTES)
+	_, err = rand.Read(secret)
+	if err != nil {
+		return res, err
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(secret)
+
+	data, err := u.feedTokenRepository.Create(ctx, entity.FeedToken{
+		UserID:     req.UserID,
+		Name:       name,
+		ActivityID: req.ActivityID,
+		TokenHash:  hashFeedToken(token),
+	})
+	if err != nil {
+		return res, err
+	}
+
+	data.CreatedAt = shared.ConvertToJakartaTime(data.CreatedAt)
+
+	res = entity.CreatedFeedToken{
+		FeedToken: data,
+		Token:     token,
+		FeedURL:   fmt.Sprintf("%s%s/%s.ics", u.publicURL, constant.FEED_PATH, token),
+		CalDAVURL: fmt.Sprintf("%s%s/%s/", u.publicURL, constant.CALDAV_PATH, data.ID),
+	}
+
+	return res, nil
+}
+
+func (u CalendarUseCase) GetAllFeedToken(ctx context.Context, userID string) ([]entity.FeedToken, error) {
+	ctx, span := tracer.Start(ctx, "CalendarUseCase.GetAllFeedToken")
+	defer span.End()
+
+	var res []entity.FeedToken
+
+	err := checkCaller(ctx, userID)
+	if err != nil {
+		return res, err
+	}
+
+	data, err := u.feedTokenRepository.GetAll(ctx, userID)
+	if err != nil {
+		return res, err
+	}
+
+	for _, token := range data {
+		token.CreatedAt = shared.ConvertToJakartaTime(token.CreatedAt)
+		token.LastUsedAt = shared.ConvertToJakartaTimePtr(token.LastUsedAt)
+		res = append(res, token)
+	}
+
+	return res, nil
+}
+
+func (u CalendarUseCase) RevokeFeedToken(ctx context.Context, userID, id string) error {
+	ctx, span := tracer.Start(ctx, "CalendarUseCase.RevokeFeedToken")
+	defer span.End()
+
+	err := checkCaller(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	err = u.feedTokenRepository.Revoke(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// AuthenticateFeedToken returns the live token matching the secret.
+func (u CalendarUseCase) AuthenticateFeedToken(ctx context.Context, token string) (entity.FeedToken, error) {
+	ctx, span := tracer.Start(ctx, "CalendarUseCase.AuthenticateFeedToken")
+	defer span.End()
+
+	if token == "" {
+		return entity.FeedToken{}, entity.ErrInvalidFeedToken
+	}
+
+	data, err := u.feedTokenRepository.Use(ctx, hashFeedToken(token))
+	if errors.Is(err, sql.ErrNoRows) {
+		return data, entity.ErrInvalidFeedToken
+	}
+
+	return data, err
+}
+
+// WriteFeed writes the tasks with a due date the token can see as one
+// iCalendar file. Tasks of deleted or archived activities are left out.
+func (u CalendarUseCase) WriteFeed(ctx context.Context, token entity.FeedToken, w io.Writer) error {
+	ctx, span := tracer.Start(ctx, "CalendarUseCase.WriteFeed")
+	defer span.End()
+
+	writer, err := exporter.NewWriter(exporter.FormatICal, w)
+	if err != nil {
+		return err
+	}
+
+	// Tasks come grouped by activity, so each activity is read once.
+	var (
+		activityID string
+		isOpen     bool
+	)
+
+	err = eachPage(func(page, limit *int32) (entity.Paging, error) {
+		tasks, paging, err := u.taskRepository.GetAllDue(ctx, entity.GetAllDueTaskRequest{
+			UserID:     token.UserID,
+			ActivityID: token.ActivityID,
+			Page:       page,
+			Limit:      limit,
+		})
+		if err != nil {
+			return paging, err
+		}
+
+		for _, task := range tasks {
+			if task.ActivityID != activityID {
+				activityID = task.ActivityID
+
+				if isOpen {
+					err = writer.EndActivity()
+					if err != nil {
+						return paging, err
+					}
+				}
+
+				activity, err := u.activityRepository.GetByID(ctx, activityID)
+				if err != nil && !errors.Is(err, sql.ErrNoRows) {
+					return paging, err
+				}
+
+				isOpen = err == nil && activity.ArchivedAt == nil
+				if isOpen {
+					err = writer.BeginActivity(exportedActivity(activity))
+					if err != nil {
+						return paging, err
+					}
+				}
+			}
+
+			if !isOpen {
+				continue
+			}
+
+			err = writer.WriteTask(exportedTask(task))
+			if err != nil {
+				return paging, err
+			}
+		}
+
+		return paging, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if isOpen {
+		err = writer.EndActivity()
+		if err != nil {
+			return err
+		}
+	}
+
+	return writer.Close()
+}
+
+// GetAllCalendar lists the activities holding tasks the token can see, each
+// of which is one CalDAV calendar.
+func (u CalendarUseCase) GetAllCalendar(ctx context.Context, token entity.FeedToken) ([]entity.Activity, error) {
+	ctx, span := tracer.Start(ctx, "CalendarUseCase.GetAllCalendar")
+	defer span.End()
+
+	var res []entity.Activity
+
+	if token.ActivityID != nil {
+		activity, err := u.GetCalendar(ctx, token, *token.ActivityID)
+		if err != nil {
+			return res, err
+		}
+
+		return append(res, activity), nil
+	}
+
+	err := eachPage(func(page, limit *int32) (entity.Paging, error) {
+		activities, paging, err := u.activityRepository.GetAll(ctx, entity.GetAllActivityRequest{
+			UserID: &token.UserID,
+			Page:   page,
+			Limit:  limit,
+		})
+		if err != nil {
+			return paging, err
+		}
+
+		for _, activity := range activities {
+			if checkActivityTypeChild(activity.Type, constant.ENTITY_TASK) == nil {
+				res = append(res, activity)
+			}
+		}
+
+		return paging, nil
+	})
+	if err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
+
+func (u CalendarUseCase) GetCalendar(ctx context.Context, token entity.FeedToken, activityID string) (entity.Activity, error) {
+	ctx, span := tracer.Start(ctx, "CalendarUseCase.GetCalendar")
+	defer span.End()
+
+	var res entity.Activity
+
+	if !shared.IsValidUUID(activityID) || (token.ActivityID != nil && *token.ActivityID != activityID) {
+		return res, fmt.Errorf("data not found")
+	}
+
+	res, err := u.activityRepository.GetByID(ctx, activityID)
+	if err != nil {
+		return res, err
+	}
+
+	if res.ArchivedAt != nil || !isActivityOwner(res, token.UserID) {
+		return res, fmt.Errorf("data not found")
+	}
+
+	err = checkActivityTypeChild(res.Type, constant.ENTITY_TASK)
+	if err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
+
+// GetAllCalendarTask lists the tasks of a calendar that are not archived.
+func (u CalendarUseCase) GetAllCalendarTask(ctx context.Context, token entity.FeedToken, activityID string) ([]entity.Task, error) {
+	ctx, span := tracer.Start(ctx, "CalendarUseCase.GetAllCalendarTask")
+	defer span.End()
+
+	var res []entity.Task
+
+	_, err := u.GetCalendar(ctx, token, activityID)
+	if err != nil {
+		return res, err
+	}
+
+	err = eachPage(func(page, limit *int32) (entity.Paging, error) {
+		tasks, paging, err := u.taskRepository.GetAll(ctx, entity.GetAllTaskRequest{
+			ActivityID: activityID,
+			Page:       page,
+			Limit:      limit,
+		})
+		if err != nil {
+			return paging, err
+		}
+
+		res = append(res, tasks...)
+
+		return paging, nil
+	})
+	if err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
+
+func (u CalendarUseCase) GetCalendarTask(ctx context.Context, token entity.FeedToken, activityID, name string) (entity.Task, error) {
+	ctx, span := tracer.Start(ctx, "CalendarUseCase.GetCalendarTask")
+	defer span.End()
+
+	_, err := u.GetCalendar(ctx, token, activityID)
+	if err != nil {
+		return entity.Task{}, err
+	}
+
+	return u.taskRepository.GetByCalDAVName(ctx, activityID, name)
+}
+
+// PutCalendarTask creates or replaces the task stored under req.Name. The
+// conditional headers of the client are checked against the current ETag so
+// concurrent edits are refused instead of lost.
+func (u CalendarUseCase) PutCalendarTask(ctx context.Context, token entity.FeedToken, req entity.CalendarTask) (entity.Task, error) {
+	ctx, span := tracer.Start(ctx, "CalendarUseCase.PutCalendarTask")
+	defer span.End()
+
+	var res entity.Task
+
+	title := strings.TrimSpace(req.Title)
+	if title == "" {
+		return res, fmt.Errorf("%w: summary is required", entity.ErrInvalidCalendarTask)
+	}
+	title = shared.TruncateTitle(title, constant.MAX_TITLE_LENGTH)
+
+	task, err := u.GetCalendarTask(ctx, token, req.ActivityID, req.Name)
+	isFound := err == nil
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return res, err
+	}
+
+	switch {
+	case isFound && req.IfNoneMatch == "*":
+		return res, fmt.Errorf("%w: %s already exists", entity.ErrPreconditionFailed, req.Name)
+	case isFound && req.IfMatch != "" && req.IfMatch != "*" && req.IfMatch != shared.TaskETag(task):
+		return res, fmt.Errorf("%w: %s was changed", entity.ErrPreconditionFailed, req.Name)
+	case !isFound && req.IfMatch != "":
+		return res, fmt.Errorf("%w: %s does not exist", entity.ErrPreconditionFailed, req.Name)
+	}
+
+	isActive := !req.Completed
+
+	var uid *string
+	if req.UID != "" {
+		uid = &req.UID
+	}
+
+	if isFound {
+		err = u.taskRepository.Update(ctx, entity.UpdateTaskRequest{
+			ID:         task.ID,
+			Title:      &title,
+			IsActive:   &isActive,
+			Priority:   &req.Priority,
+			DueAt:      req.DueAt,
+			ICalUID:    uid,
+			ClearDueAt: req.DueAt == nil,
+		})
+	} else {
+		err = u.transactor.WithTx(ctx, func(ctx context.Context) error {
+			err := checkTaskQuota(ctx, u.taskRepository, u.quota, req.ActivityID, 1)
+			if err != nil {
+				return err
+			}
+
+			return u.taskRepository.Create(ctx, entity.CreateTaskRequest{
+				Title:      title,
+				ActivityID: req.ActivityID,
+				IsActive:   &isActive,
+				Priority:   req.Priority,
+				DueAt:      req.DueAt,
+				ICalUID:    uid,
+				CalDAVName: &req.Name,
+			})
+		})
+	}
+	if err != nil {
+		return res, err
+	}
+
+	if !isFound {
+		tasksCreated.WithLabelValues(sourceCalendar).Inc()
+	}
+
+	if req.Completed && (!isFound || task.IsActive) {
+		tasksCompleted.WithLabelValues(sourceCalendar).Inc()
+	}
+
+	return u.taskRepository.GetByCalDAVName(ctx, req.ActivityID, req.Name)
+}
+
+func (u CalendarUseCase) DeleteCalendarTask(ctx context.Context, token entity.FeedToken, activityID, name string) error {
+	ctx, span := tracer.Start(ctx, "CalendarUseCase.DeleteCalendarTask")
+	defer span.End()
+
+	task, err := u.GetCalendarTask(ctx, token, activityID, name)
+	if err != nil {
+		return err
+	}
+
+	err = u.taskRepository.Delete(ctx, task.ID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// isActivityOwner reports whether the activity belongs to the user. Activities
+// created without a user belong to nobody.
+func isActivityOwner(activity entity.Activity, userID string) bool {
+	return activity.UserID != nil && *activity.UserID == userID
+}
+
+// checkCaller fails unless userID is the user the request is made for. Feed
+// tokens read every task of their user, so only that user manages them.
+func checkCaller(ctx context.Context, userID string) error {
+	caller, ok := identity.UserIDFromContext(ctx)
+	if !ok || caller != userID {
+		return fmt.Errorf("%w: user_id does not match the caller", entity.ErrForbidden)
+	}
+
+	return nil
+}
+
+func hashFeedToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,181 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/digisata/todo-service/internal/constant"
+	"github.com/digisata/todo-service/internal/entity"
+	"github.com/digisata/todo-service/internal/shared"
+)
+
+type CommentUseCase struct {
+	commentRepository CommentRepository
+	taskRepository    TaskRepository
+}
+
+func NewComment(commentRepository CommentRepository, taskRepository TaskRepository) *CommentUseCase {
+	return &CommentUseCase{
+		commentRepository: commentRepository,
+		taskRepository:    taskRepository,
+	}
+}
+
+// CreateComment posts a comment as the caller, the user in the x-user-id
+// metadata.
+func (u CommentUseCase) CreateComment(ctx context.Context, req entity.CreateCommentRequest) (entity.Comment, error) {
+	ctx, span := tracer.Start(ctx, "CommentUseCase.CreateComment")
+	defer span.End()
+
+	var res entity.Comment
+
+	authorID := shared.Deref(requestUserID(ctx))
+	if authorID == "" {
+		return res, fmt.Errorf("%w: comments need a caller", entity.ErrForbidden)
+	}
+
+	req.AuthorID = authorID
+
+	err := validateCommentBody(req.Body)
+	if err != nil {
+		return res, err
+	}
+
+	_, err = u.taskRepository.GetByID(ctx, req.TaskID)
+	if err != nil {
+		return res, err
+	}
+
+	res, err = u.commentRepository.Create(ctx, req)
+	if err != nil {
+		return res, err
+	}
+
+	res.CreatedAt = shared.ConvertToJakartaTime(res.CreatedAt)
+	res.UpdatedAt = shared.ConvertToJakartaTime(res.UpdatedAt)
+
+	return res, nil
+}
+
+// UpdateComment edits a comment. Only its author may.
+func (u CommentUseCase) UpdateComment(ctx context.Context, req entity.UpdateCommentRequest) error {
+	ctx, span := tracer.Start(ctx, "CommentUseCase.UpdateComment")
+	defer span.End()
+
+	err := validateCommentBody(req.Body)
+	if err != nil {
+		return err
+	}
+
+	err = u.checkCommentAuthor(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+
+	err = u.commentRepository.Update(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteComment removes a comment. Only its author may.
+func (u CommentUseCase) DeleteComment(ctx context.Context, id string) error {
+	ctx, span := tracer.Start(ctx, "CommentUseCase.DeleteComment")
+	defer span.End()
+
+	err := u.checkCommentAuthor(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	err = u.commentRepository.Delete(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (u CommentUseCase) GetAllComment(ctx context.Context, req entity.GetAllCommentRequest) ([]entity.Comment, entity.Paging, error) {
+	ctx, span := tracer.Start(ctx, "CommentUseCase.GetAllComment")
+	defer span.End()
+
+	if req.Cursor != nil && *req.Cursor != "" {
+		after, err := shared.DecodeCursor(*req.Cursor)
+		if err != nil {
+			return nil, entity.Paging{}, err
+		}
+
+		req.After = &after
+	}
+
+	res, paging, err := u.commentRepository.GetAll(ctx, req)
+	if err != nil {
+		return res, paging, err
+	}
+
+	for i := 0; i < len(res); i++ {
+		res[i].CreatedAt = shared.ConvertToJakartaTime(res[i].CreatedAt)
+		res[i].UpdatedAt = shared.ConvertToJakartaTime(res[i].UpdatedAt)
+		res[i].EditedAt = shared.ConvertToJakartaTimePtr(res[i].EditedAt)
+	}
+
+	return res, paging, nil
+}
+
+func (u CommentUseCase) GetActivityFeed(ctx context.Context, req entity.GetFeedRequest) ([]entity.FeedItem, entity.Paging, error) {
+	ctx, span := tracer.Start(ctx, "CommentUseCase.GetActivityFeed")
+	defer span.End()
+
+	if req.Cursor != nil && *req.Cursor != "" {
+		before, err := shared.DecodeCursor(*req.Cursor)
+		if err != nil {
+			return nil, entity.Paging{}, err
+		}
+
+		req.Before = &before
+	}
+
+	res, paging, err := u.commentRepository.GetFeed(ctx, req)
+	if err != nil {
+		return res, paging, err
+	}
+
+	for i := 0; i < len(res); i++ {
+		res[i].CreatedAt = shared.ConvertToJakartaTime(res[i].CreatedAt)
+	}
+
+	return res, paging, nil
+}
+
+// checkCommentAuthor refuses callers other than the author of the comment.
+// The author never changes, so the check holds for the write that follows.
+func (u CommentUseCase) checkCommentAuthor(ctx context.Context, id string) error {
+	comment, err := u.commentRepository.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	callerID := shared.Deref(requestUserID(ctx))
+	if callerID == "" || callerID != comment.AuthorID {
+		return fmt.Errorf("%w: only the author may change a comment", entity.ErrForbidden)
+	}
+
+	return nil
+}
+
+func validateCommentBody(body string) error {
+	if strings.TrimSpace(body) == "" {
+		return fmt.Errorf("%w: body is required", entity.ErrInvalidComment)
+	}
+
+	if utf8.RuneCountInString(body) > constant.MAX_COMMENT_LENGTH {
+		return fmt.Errorf("%w: body must be at most %d characters", entity.ErrInvalidComment, constant.MAX_COMMENT_LENGTH)
+	}
+
+	return nil
+}
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"io"
 
 	"github.com/digisata/todo-service/internal/entity"
 )
@@ -13,14 +14,26 @@ type (
 		GetAll(ctx context.Context, req entity.GetAllTaskRequest) ([]entity.Task, entity.Paging, error)
 		GetByID(ctx context.Context, id string) (entity.Task, error)
 		Delete(ctx context.Context, id string) error
+		ArchiveCompleted(ctx context.Context, activityID string) (int64, error)
+		BulkCreate(ctx context.Context, req []entity.CreateTaskRequest) ([]entity.BulkTaskResult, error)
+		BulkDelete(ctx context.Context, req entity.BulkTaskRequest) ([]entity.BulkTaskResult, error)
+		BulkComplete(ctx context.Context, req entity.BulkTaskRequest) ([]entity.BulkTaskResult, error)
+		BulkMove(ctx context.Context, req entity.BulkMoveTaskRequest) ([]entity.BulkTaskResult, error)
+		GetAllDue(ctx context.Context, req entity.GetAllDueTaskRequest) ([]entity.Task, entity.Paging, error)
+		GetByCalDAVName(ctx context.Context, activityID, name string) (entity.Task, error)
+		CountByActivity(ctx context.Context, activityID string) (int, error)
 	}
 
 	ActivityRepository interface {
-		Create(ctx context.Context, req entity.CreateActivityRequest) error
+		Create(ctx context.Context, req entity.CreateActivityRequest) (string, error)
 		Update(ctx context.Context, req entity.UpdateActivityRequest) error
 		GetAll(ctx context.Context, req entity.GetAllActivityRequest) ([]entity.Activity, entity.Paging, error)
 		GetByID(ctx context.Context, id string) (entity.Activity, error)
 		Delete(ctx context.Context, id string) error
+		Archive(ctx context.Context, id string) error
+		Unarchive(ctx context.Context, id string) error
+		CountByUser(ctx context.Context, userID *string) (int, error)
+		CountChildren(ctx context.Context, activityID, child string) (int, error)
 	}
 
 	TextRepository interface {
@@ -29,5 +42,62 @@ type (
 		GetAll(ctx context.Context, req entity.GetAllTextRequest) ([]entity.Text, entity.Paging, error)
 		GetByID(ctx context.Context, id string) (entity.Text, error)
 		Delete(ctx context.Context, id string) error
+		GetAllRevision(ctx context.Context, req entity.GetAllTextRevisionRequest) ([]entity.TextRevision, entity.Paging, error)
+		GetRevision(ctx context.Context, req entity.GetTextRevisionRequest) (entity.TextRevision, error)
+		RestoreRevision(ctx context.Context, req entity.GetTextRevisionRequest) error
+		GetUnsanitized(ctx context.Context, limit int) ([]entity.Text, error)
+		UpdateSanitized(ctx context.Context, req entity.Text) error
+		GetUnsanitizedRevisions(ctx context.Context, limit int) ([]entity.TextRevision, error)
+		UpdateSanitizedRevision(ctx context.Context, req entity.TextRevision) error
+	}
+
+	EventRepository interface {
+		GetAll(ctx context.Context, req entity.GetAllEventRequest) ([]entity.Event, bool, error)
+		GetCurrentCursor(ctx context.Context) (entity.EventCursor, error)
+	}
+
+	SyncRepository interface {
+		GetLastToken(ctx context.Context) (int64, error)
+		GetChanges(ctx context.Context, req entity.GetChangesRequest) (entity.Changes, error)
+		ApplyMutations(ctx context.Context, req []entity.SyncMutation) ([]entity.SyncMutationResult, error)
+	}
+
+	AttachmentRepository interface {
+		Create(ctx context.Context, req entity.Attachment) error
+		GetAll(ctx context.Context, req entity.GetAllAttachmentRequest) ([]entity.Attachment, error)
+		GetByID(ctx context.Context, id string) (entity.Attachment, error)
+		Delete(ctx context.Context, id string) error
+	}
+
+	CommentRepository interface {
+		Create(ctx context.Context, req entity.CreateCommentRequest) (entity.Comment, error)
+		GetByID(ctx context.Context, id string) (entity.Comment, error)
+		Update(ctx context.Context, req entity.UpdateCommentRequest) error
+		Delete(ctx context.Context, id string) error
+		GetAll(ctx context.Context, req entity.GetAllCommentRequest) ([]entity.Comment, entity.Paging, error)
+		GetFeed(ctx context.Context, req entity.GetFeedRequest) ([]entity.FeedItem, entity.Paging, error)
+	}
+
+	FeedTokenRepository interface {
+		Create(ctx context.Context, req entity.FeedToken) (entity.FeedToken, error)
+		GetAll(ctx context.Context, userID string) ([]entity.FeedToken, error)
+		Use(ctx context.Context, tokenHash string) (entity.FeedToken, error)
+		Revoke(ctx context.Context, userID, id string) error
+	}
+
+	BlobStore interface {
+		Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+		Get(ctx context.Context, key string) (io.ReadCloser, error)
+		Delete(ctx context.Context, key string) error
+	}
+
+	// Transactor runs fn in one transaction that repositories join through
+	// the context.
+	Transactor interface {
+		WithTx(ctx context.Context, fn func(ctx context.Context) error) error
+	}
+
+	EventNotifier interface {
+		Subscribe(key string) (<-chan struct{}, func())
 	}
 )
@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"github.com/digisata/todo-service/internal/constant"
+	"github.com/digisata/todo-service/internal/entity"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Sources label where a task change came from.
+const (
+	sourceAPI      = "api"
+	sourceBulk     = "bulk"
+	sourceCalendar = "calendar"
+	sourceImport   = "import"
+	sourceSync     = "sync"
+)
+
+var (
+	tasksCreated = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "todo",
+		Name:      "tasks_created_total",
+		Help:      "Tasks created, by source.",
+	}, []string{"source"})
+
+	tasksCompleted = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "todo",
+		Name:      "tasks_completed_total",
+		Help:      "Tasks marked as completed, by source.",
+	}, []string{"source"})
+)
+
+func countBulkSuccess(counter *prometheus.CounterVec, source string, results []entity.BulkTaskResult) {
+	var n int
+	for _, result := range results {
+		if result.Status == constant.BULK_STATUS_SUCCESS {
+			n++
+		}
+	}
+
+	counter.WithLabelValues(source).Add(float64(n))
+}
+
+func isCompleting(isActive *bool) bool {
+	return isActive != nil && !*isActive
+}
+
+func countSyncMutation(mutation entity.SyncMutation, result entity.SyncMutationResult) {
+	if mutation.EntityType != constant.ENTITY_TASK || result.Status != constant.SYNC_STATUS_APPLIED {
+		return
+	}
+
+	switch mutation.Operation {
+	case constant.SYNC_OPERATION_CREATE:
+		tasksCreated.WithLabelValues(sourceSync).Inc()
+		if isCompleting(mutation.IsActive) {
+			tasksCompleted.WithLabelValues(sourceSync).Inc()
+		}
+	case constant.SYNC_OPERATION_UPDATE:
+		if isCompleting(mutation.IsActive) {
+			tasksCompleted.WithLabelValues(sourceSync).Inc()
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/digisata/todo-service/internal/entity"
+	"github.com/digisata/todo-service/pkg/identity"
+	"github.com/digisata/todo-service/pkg/ot"
+)
+
+// checkActivityQuota fails when adding activities would take the user of
+// the request over the quota. Requests without a user share the quota of the
+// activities that have no owner. Run it in the transaction that creates the
+// activities: the count locks the user until that transaction ends, so
+// concurrent requests cannot both pass it.
+func checkActivityQuota(ctx context.Context, activityRepository ActivityRepository, quota entity.Quota, adding int) error {
+	if quota.MaxActivities <= 0 {
+		return nil
+	}
+
+	count, err := activityRepository.CountByUser(ctx, requestUserID(ctx))
+	if err != nil {
+		return err
+	}
+
+	if count+adding > quota.MaxActivities {
+		return fmt.Errorf("%w: at most %d activities per user", entity.ErrQuotaExceeded, quota.MaxActivities)
+	}
+
+	return nil
+}
+
+// checkTaskQuota fails when adding tasks would take the activity over the
+// quota. Like checkActivityQuota it belongs in the transaction that adds
+// them, where the count locks the activity.
+func checkTaskQuota(ctx context.Context, taskRepository TaskRepository, quota entity.Quota, activityID string, adding int) error {
+	if quota.MaxTasksPerActivity <= 0 {
+		return nil
+	}
+
+	count, err := taskRepository.CountByActivity(ctx, activityID)
+	if err != nil {
+		return err
+	}
+
+	return checkTaskCount(quota, count+adding)
+}
+
+func checkTaskCount(quota entity.Quota, count int) error {
+	if quota.MaxTasksPerActivity > 0 && count > quota.MaxTasksPerActivity {
+		return fmt.Errorf("%w: at most %d tasks per activity", entity.ErrQuotaExceeded, quota.MaxTasksPerActivity)
+	}
+
+	return nil
+}
+
+// checkNoteSize takes the stored HTML, not the text sent by the client.
+func checkNoteSize(quota entity.Quota, content string) error {
+	if quota.MaxNoteSize > 0 && len(content) > quota.MaxNoteSize {
+		return fmt.Errorf("%w: notes are at most %d bytes", entity.ErrQuotaExceeded, quota.MaxNoteSize)
+	}
+
+	return nil
+}
+
+// checkEditSize rejects edits that grow a shared document past the note
+// size. Deleted text is not subtracted, so an edit that replaces text close to
+// the limit can be refused; edits that only shrink the document always pass.
+func checkEditSize(quota entity.Quota, doc string, op ot.Operation) error {
+	if quota.MaxNoteSize <= 0 || op.TargetLen() <= op.BaseLen() {
+		return nil
+	}
+
+	size := len(doc)
+	for _, c := range op {
+		size += len(c.Insert)
+	}
+
+	if size > quota.MaxNoteSize {
+		return fmt.Errorf("%w: notes are at most %d bytes", entity.ErrQuotaExceeded, quota.MaxNoteSize)
+	}
+
+	return nil
+}
+
+// requestUserID is the user of the request to store as the owner, if any.
+func requestUserID(ctx context.Context) *string {
+	userID, ok := identity.UserIDFromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	return &userID
+}
@@ -0,0 +1,402 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/digisata/todo-service/internal/constant"
+	"github.com/digisata/todo-service/internal/entity"
+	"github.com/digisata/todo-service/internal/shared"
+)
+
+type SyncUseCase struct {
+	syncRepository     SyncRepository
+	activityRepository ActivityRepository
+	taskRepository     TaskRepository
+	transactor         Transactor
+	quota              entity.Quota
+}
+
+func NewSync(syncRepository SyncRepository, activityRepository ActivityRepository, taskRepository TaskRepository, transactor Transactor, quota entity.Quota) *SyncUseCase {
+	return &SyncUseCase{
+		syncRepository:     syncRepository,
+		activityRepository: activityRepository,
+		taskRepository:     taskRepository,
+		transactor:         transactor,
+		quota:              quota,
+	}
+}
+
+// Sync applies the client mutations and then returns everything of the caller
+// that changed since req.SinceToken, including the effect of the mutations
+// just applied unless an older transaction is still running; then it comes
+// with the next token. Without req.SinceToken it returns a page of the
+// snapshot, and the next token only with the last page.
+func (u SyncUseCase) Sync(ctx context.Context, req entity.SyncRequest) (entity.SyncResponse, error) {
+	ctx, span := tracer.Start(ctx, "SyncUseCase.Sync")
+	defer span.End()
+
+	var (
+		res     entity.SyncResponse
+		valid   []entity.SyncMutation
+		indexes []int
+		after   *entity.SnapshotCursor
+	)
+
+	if req.SinceToken == nil && req.SnapshotCursor != nil && *req.SnapshotCursor != "" {
+		cursor, err := shared.DecodeSnapshotCursor(*req.SnapshotCursor)
+		if err != nil {
+			return res, err
+		}
+
+		after = &cursor
+	}
+
+	// Types of the activities created in this batch, for their children
+	batchTypes := make(map[string]string)
+	usage := syncUsage{tasks: make(map[string]int)}
+
+	res.Results = make([]entity.SyncMutationResult, len(req.Mutations))
+
+	// The quotas are checked in the transaction that applies the mutations
+	err := u.transactor.WithTx(ctx, func(ctx context.Context) error {
+		err := u.lockSyncQuotas(ctx, req.Mutations)
+		if err != nil {
+			return err
+		}
+
+		for i, mutation := range req.Mutations {
+			message := validateSyncMutation(mutation)
+			if message == "" {
+				var err error
+				message, err = u.validateSyncActivityType(ctx, &mutation, batchTypes)
+				if err != nil {
+					return err
+				}
+			}
+
+			if mutation.EntityType == constant.ENTITY_TEXT && mutation.Text != nil && message == "" {
+				content, plainText, err := renderText(*mutation.Text, constant.TEXT_FORMAT_HTML)
+				if err != nil {
+					return err
+				}
+
+				mutation.Text = &content
+				mutation.PlainText = &plainText
+			}
+
+			if message == "" {
+				var err error
+				message, err = u.checkSyncQuota(ctx, mutation, &usage)
+				if err != nil {
+					return err
+				}
+			}
+
+			if message != "" {
+				res.Results[i] = entity.SyncMutationResult{
+					ClientMutationID: mutation.ClientMutationID,
+					EntityID:         mutation.EntityID,
+					Status:           constant.SYNC_STATUS_INVALID,
+					Message:          message,
+				}
+				continue
+			}
+
+			if mutation.EntityType == constant.ENTITY_ACTIVITY && mutation.Operation == constant.SYNC_OPERATION_CREATE {
+				mutation.UserID = requestUserID(ctx)
+			}
+
+			if mutation.BaseUpdatedAt != nil {
+				baseUpdatedAt := shared.ConvertFromJakartaTime(*mutation.BaseUpdatedAt)
+				mutation.BaseUpdatedAt = &baseUpdatedAt
+			}
+
+			valid = append(valid, mutation)
+			indexes = append(indexes, i)
+		}
+
+		if len(valid) > 0 {
+			results, err := u.syncRepository.ApplyMutations(ctx, valid)
+			if err != nil {
+				return err
+			}
+
+			for i, result := range results {
+				res.Results[indexes[i]] = result
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return res, err
+	}
+
+	for i, mutation := range valid {
+		countSyncMutation(mutation, res.Results[indexes[i]])
+	}
+
+	// Later pages of a snapshot keep the token of its first page, so the
+	// changes made while paging are picked up by the next sync
+	var token int64
+	if after != nil {
+		token = after.Token
+	} else {
+		token, err = u.syncRepository.GetLastToken(ctx)
+		if err != nil {
+			return res, err
+		}
+	}
+
+	changes, err := u.syncRepository.GetChanges(ctx, entity.GetChangesRequest{
+		UserID: requestUserID(ctx),
+		Since:  req.SinceToken,
+		Until:  token,
+		Limit:  req.Limit,
+		After:  after,
+	})
+	if err != nil {
+		return res, err
+	}
+
+	for i := 0; i < len(changes.Activities); i++ {
+		changes.Activities[i].CreatedAt = shared.ConvertToJakartaTime(changes.Activities[i].CreatedAt)
+		changes.Activities[i].UpdatedAt = shared.ConvertToJakartaTime(changes.Activities[i].UpdatedAt)
+		changes.Activities[i].DeletedAt = shared.ConvertToJakartaTimePtr(changes.Activities[i].DeletedAt)
+		changes.Activities[i].ArchivedAt = shared.ConvertToJakartaTimePtr(changes.Activities[i].ArchivedAt)
+	}
+
+	for i := 0; i < len(changes.Tasks); i++ {
+		changes.Tasks[i].CreatedAt = shared.ConvertToJakartaTime(changes.Tasks[i].CreatedAt)
+		changes.Tasks[i].UpdatedAt = shared.ConvertToJakartaTime(changes.Tasks[i].UpdatedAt)
+		changes.Tasks[i].DeletedAt = shared.ConvertToJakartaTimePtr(changes.Tasks[i].DeletedAt)
+		changes.Tasks[i].ArchivedAt = shared.ConvertToJakartaTimePtr(changes.Tasks[i].ArchivedAt)
+	}
+
+	for i := 0; i < len(changes.Texts); i++ {
+		changes.Texts[i].CreatedAt = shared.ConvertToJakartaTime(changes.Texts[i].CreatedAt)
+		changes.Texts[i].UpdatedAt = shared.ConvertToJakartaTime(changes.Texts[i].UpdatedAt)
+		changes.Texts[i].DeletedAt = shared.ConvertToJakartaTimePtr(changes.Texts[i].DeletedAt)
+	}
+
+	if changes.NextCursor != "" {
+		res.NextSnapshotCursor = changes.NextCursor
+	} else {
+		res.NextToken = token
+	}
+
+	res.Activities = changes.Activities
+	res.Tasks = changes.Tasks
+	res.Texts = changes.Texts
+
+	return res, nil
+}
+
+// syncUsage counts what a batch adds, so the quotas hold for the batch as a
+// whole and not only for each mutation.
+type syncUsage struct {
+	activities int
+	tasks      map[string]int
+}
+
+// lockSyncQuotas takes the quota locks of the batch up front, the user first
+// and then the activities in order, as the other usecases do, so that
+// concurrent requests cannot deadlock on them.
+func (u SyncUseCase) lockSyncQuotas(ctx context.Context, mutations []entity.SyncMutation) error {
+	var (
+		createsActivity bool
+		activityIDs     []string
+	)
+
+	for _, mutation := range mutations {
+		if mutation.Operation != constant.SYNC_OPERATION_CREATE {
+			continue
+		}
+
+		switch mutation.EntityType {
+		case constant.ENTITY_ACTIVITY:
+			createsActivity = true
+		case constant.ENTITY_TASK:
+			activityID := strings.ToLower(mutation.ActivityID)
+			if shared.IsValidUUID(activityID) {
+				activityIDs = append(activityIDs, activityID)
+			}
+		}
+	}
+
+	if createsActivity && u.quota.MaxActivities > 0 {
+		_, err := u.activityRepository.CountByUser(ctx, requestUserID(ctx))
+		if err != nil {
+			return err
+		}
+	}
+
+	if u.quota.MaxTasksPerActivity <= 0 {
+		return nil
+	}
+
+	slices.Sort(activityIDs)
+	for _, activityID := range slices.Compact(activityIDs) {
+		_, err := u.taskRepository.CountByActivity(ctx, activityID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkSyncQuota returns why the mutation is over a quota, if it is. Failed
+// mutations still count towards the batch, which errs on the safe side.
+func (u SyncUseCase) checkSyncQuota(ctx context.Context, mutation entity.SyncMutation, usage *syncUsage) (string, error) {
+	if mutation.EntityType == constant.ENTITY_TEXT && mutation.Text != nil {
+		err := checkNoteSize(u.quota, *mutation.Text)
+		if err != nil {
+			return err.Error(), nil
+		}
+	}
+
+	if mutation.Operation != constant.SYNC_OPERATION_CREATE {
+		return "", nil
+	}
+
+	switch mutation.EntityType {
+	case constant.ENTITY_ACTIVITY:
+		err := checkActivityQuota(ctx, u.activityRepository, u.quota, usage.activities+1)
+		if errors.Is(err, entity.ErrQuotaExceeded) {
+			return err.Error(), nil
+		}
+
+		if err != nil {
+			return "", err
+		}
+
+		usage.activities++
+	case constant.ENTITY_TASK:
+		activityID := strings.ToLower(mutation.ActivityID)
+		err := checkTaskQuota(ctx, u.taskRepository, u.quota, activityID, usage.tasks[activityID]+1)
+		if errors.Is(err, entity.ErrQuotaExceeded) {
+			return err.Error(), nil
+		}
+
+		if err != nil {
+			return "", err
+		}
+
+		usage.tasks[activityID]++
+	}
+
+	return "", nil
+}
+
+// validateSyncActivityType resolves activity types to registered names and
+// checks that the parent activity accepts the created task or text.
+func (u SyncUseCase) validateSyncActivityType(ctx context.Context, mutation *entity.SyncMutation, batchTypes map[string]string) (string, error) {
+	if mutation.EntityType == constant.ENTITY_ACTIVITY {
+		if mutation.Operation == constant.SYNC_OPERATION_DELETE {
+			return "", nil
+		}
+
+		activityType, ok := shared.GetActivityType(shared.Deref(mutation.Type))
+		if mutation.Type != nil || mutation.Operation == constant.SYNC_OPERATION_CREATE {
+			if !ok {
+				return entity.ErrInvalidActivityType.Error(), nil
+			}
+
+			mutation.Type = &activityType.Name
+		}
+
+		if mutation.Type != nil && mutation.Operation == constant.SYNC_OPERATION_UPDATE {
+			err := checkActivityChildren(ctx, u.activityRepository, mutation.EntityID, activityType.Name)
+			if errors.Is(err, entity.ErrChildNotAllowed) {
+				return err.Error(), nil
+			}
+
+			if err != nil {
+				return "", err
+			}
+		}
+
+		if mutation.Title != nil {
+			err := shared.ValidateActivityTitle(*mutation.Title)
+			if err != nil {
+				return err.Error(), nil
+			}
+		}
+
+		if mutation.Operation == constant.SYNC_OPERATION_CREATE {
+			batchTypes[strings.ToLower(mutation.EntityID)] = activityType.Name
+		}
+
+		return "", nil
+	}
+
+	if mutation.Operation != constant.SYNC_OPERATION_CREATE {
+		return "", nil
+	}
+
+	activityType, ok := batchTypes[strings.ToLower(mutation.ActivityID)]
+	if !ok {
+		activity, err := u.activityRepository.GetByID(ctx, mutation.ActivityID)
+		if errors.Is(err, sql.ErrNoRows) {
+			// Reported as not found by the repository
+			return "", nil
+		}
+
+		if err != nil {
+			return "", err
+		}
+
+		activityType = activity.Type
+	}
+
+	err := checkActivityTypeChild(activityType, mutation.EntityType)
+	if err != nil {
+		return err.Error(), nil
+	}
+
+	return "", nil
+}
+
+func validateSyncMutation(mutation entity.SyncMutation) string {
+	switch mutation.EntityType {
+	case constant.ENTITY_ACTIVITY, constant.ENTITY_TASK, constant.ENTITY_TEXT:
+	default:
+		return "unknown entity type"
+	}
+
+	switch mutation.Operation {
+	case constant.SYNC_OPERATION_CREATE, constant.SYNC_OPERATION_UPDATE, constant.SYNC_OPERATION_DELETE:
+	default:
+		return "unknown operation"
+	}
+
+	if !shared.IsValidUUID(mutation.EntityID) {
+		return "entity id must be a valid UUID"
+	}
+
+	// Activity titles are checked with their type
+	if mutation.EntityType == constant.ENTITY_TASK && utf8.RuneCountInString(shared.Deref(mutation.Title)) > constant.MAX_TITLE_LENGTH {
+		return fmt.Sprintf("title must be at most %d characters", constant.MAX_TITLE_LENGTH)
+	}
+
+	if mutation.Operation != constant.SYNC_OPERATION_CREATE {
+		return ""
+	}
+
+	if mutation.EntityType != constant.ENTITY_ACTIVITY && !shared.IsValidUUID(mutation.ActivityID) {
+		return "activity id must be a valid UUID"
+	}
+
+	if mutation.EntityType != constant.ENTITY_TEXT && shared.Deref(mutation.Title) == "" {
+		return "title is required"
+	}
+
+	return ""
+}
@@ -2,49 +2,101 @@ package usecase
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
 
+	"github.com/digisata/todo-service/internal/constant"
 	"github.com/digisata/todo-service/internal/entity"
 	"github.com/digisata/todo-service/internal/shared"
 )
 
 type TaskUseCase struct {
-	taskRepository TaskRepository
+	taskRepository     TaskRepository
+	activityRepository ActivityRepository
+	transactor         Transactor
+	quota              entity.Quota
 }
 
-func NewTask(taskRepository TaskRepository) *TaskUseCase {
-	return &TaskUseCase{taskRepository: taskRepository}
+func NewTask(taskRepository TaskRepository, activityRepository ActivityRepository, transactor Transactor, quota entity.Quota) *TaskUseCase {
+	return &TaskUseCase{
+		taskRepository:     taskRepository,
+		activityRepository: activityRepository,
+		transactor:         transactor,
+		quota:              quota,
+	}
 }
 
 func (u TaskUseCase) CreateTask(ctx context.Context, req entity.CreateTaskRequest) error {
-	err := u.taskRepository.Create(ctx, req)
+	ctx, span := tracer.Start(ctx, "TaskUseCase.CreateTask")
+	defer span.End()
+
+	err := checkActivityChild(ctx, u.activityRepository, req.ActivityID, constant.ENTITY_TASK)
+	if err != nil {
+		return err
+	}
+
+	err = u.transactor.WithTx(ctx, func(ctx context.Context) error {
+		err := checkTaskQuota(ctx, u.taskRepository, u.quota, req.ActivityID, 1)
+		if err != nil {
+			return err
+		}
+
+		return u.taskRepository.Create(ctx, req)
+	})
 	if err != nil {
 		return err
 	}
 
+	tasksCreated.WithLabelValues(sourceAPI).Inc()
+	if isCompleting(req.IsActive) {
+		tasksCompleted.WithLabelValues(sourceAPI).Inc()
+	}
+
 	return nil
 }
 
 func (u TaskUseCase) UpdateTask(ctx context.Context, req entity.UpdateTaskRequest) error {
+	ctx, span := tracer.Start(ctx, "TaskUseCase.UpdateTask")
+	defer span.End()
+
 	err := u.taskRepository.Update(ctx, req)
 	if err != nil {
 		return err
 	}
 
+	if isCompleting(req.IsActive) {
+		tasksCompleted.WithLabelValues(sourceAPI).Inc()
+	}
+
 	return nil
 }
 
 func (u TaskUseCase) BatchUpdateTask(ctx context.Context, req []entity.UpdateTaskRequest) error {
+	ctx, span := tracer.Start(ctx, "TaskUseCase.BatchUpdateTask")
+	defer span.End()
+
 	for _, task := range req {
 		err := u.taskRepository.Update(ctx, task)
 		if err != nil {
 			return err
 		}
+
+		if isCompleting(task.IsActive) {
+			tasksCompleted.WithLabelValues(sourceAPI).Inc()
+		}
 	}
 
 	return nil
 }
 
 func (u TaskUseCase) GetTask(ctx context.Context, id string) (entity.Task, error) {
+	ctx, span := tracer.Start(ctx, "TaskUseCase.GetTask")
+	defer span.End()
+
 	var res entity.Task
 	res, err := u.taskRepository.GetByID(ctx, id)
 	if err != nil {
@@ -53,11 +105,16 @@ func (u TaskUseCase) GetTask(ctx context.Context, id string) (entity.Task, error
 
 	res.CreatedAt = shared.ConvertToJakartaTime(res.CreatedAt)
 	res.UpdatedAt = shared.ConvertToJakartaTime(res.UpdatedAt)
+	res.ArchivedAt = shared.ConvertToJakartaTimePtr(res.ArchivedAt)
+	res.DueAt = shared.ConvertToJakartaTimePtr(res.DueAt)
 
 	return res, nil
 }
 
 func (u TaskUseCase) GetAllTaskByActivityID(ctx context.Context, req entity.GetAllTaskRequest) ([]entity.Task, entity.Paging, error) {
+	ctx, span := tracer.Start(ctx, "TaskUseCase.GetAllTaskByActivityID")
+	defer span.End()
+
 	var (
 		res    []entity.Task
 		paging entity.Paging
@@ -70,12 +127,17 @@ func (u TaskUseCase) GetAllTaskByActivityID(ctx context.Context, req entity.GetA
 	for i := 0; i < len(res); i++ {
 		res[i].CreatedAt = shared.ConvertToJakartaTime(res[i].CreatedAt)
 		res[i].UpdatedAt = shared.ConvertToJakartaTime(res[i].UpdatedAt)
+		res[i].ArchivedAt = shared.ConvertToJakartaTimePtr(res[i].ArchivedAt)
+		res[i].DueAt = shared.ConvertToJakartaTimePtr(res[i].DueAt)
 	}
 
 	return res, paging, nil
 }
 
 func (u TaskUseCase) DeleteTask(ctx context.Context, id string) error {
+	ctx, span := tracer.Start(ctx, "TaskUseCase.DeleteTask")
+	defer span.End()
+
 	err := u.taskRepository.Delete(ctx, id)
 	if err != nil {
 		return err
@@ -83,3 +145,243 @@ func (u TaskUseCase) DeleteTask(ctx context.Context, id string) error {
 
 	return nil
 }
+
+func (u TaskUseCase) ArchiveCompletedTasks(ctx context.Context, activityID string) (int64, error) {
+	ctx, span := tracer.Start(ctx, "TaskUseCase.ArchiveCompletedTasks")
+	defer span.End()
+
+	count, err := u.taskRepository.ArchiveCompleted(ctx, activityID)
+	if err != nil {
+		return count, err
+	}
+
+	return count, nil
+}
+
+func (u TaskUseCase) BulkCreateTask(ctx context.Context, req []entity.CreateTaskRequest) ([]entity.BulkTaskResult, error) {
+	ctx, span := tracer.Start(ctx, "TaskUseCase.BulkCreateTask")
+	defer span.End()
+
+	var (
+		valid   []entity.CreateTaskRequest
+		indexes []int
+	)
+
+	// Activities that do not exist are left to the repository to report
+	allowed := make(map[string]bool)
+	for _, task := range req {
+		activityID := strings.ToLower(task.ActivityID)
+		if _, ok := allowed[activityID]; ok || !shared.IsValidUUID(activityID) {
+			continue
+		}
+
+		err := checkActivityChild(ctx, u.activityRepository, activityID, constant.ENTITY_TASK)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) && !errors.Is(err, entity.ErrChildNotAllowed) {
+			return nil, err
+		}
+
+		allowed[activityID] = !errors.Is(err, entity.ErrChildNotAllowed)
+	}
+
+	var (
+		res     []entity.BulkTaskResult
+		results []entity.BulkTaskResult
+	)
+
+	// The quota is counted in the transaction that inserts the tasks
+	err := u.transactor.WithTx(ctx, func(ctx context.Context) error {
+		// Tasks over the quota of their activity are reported as invalid.
+		// Activities are counted, and so locked, in a fixed order so that
+		// concurrent batches cannot deadlock.
+		remaining := make(map[string]int)
+		if u.quota.MaxTasksPerActivity > 0 {
+			var activityIDs []string
+			for activityID, ok := range allowed {
+				if ok {
+					activityIDs = append(activityIDs, activityID)
+				}
+			}
+			slices.Sort(activityIDs)
+
+			for _, activityID := range activityIDs {
+				count, err := u.taskRepository.CountByActivity(ctx, activityID)
+				if err != nil {
+					return err
+				}
+
+				remaining[activityID] = u.quota.MaxTasksPerActivity - count
+			}
+		}
+
+		res = make([]entity.BulkTaskResult, len(req))
+		for i, task := range req {
+			res[i] = entity.BulkTaskResult{Index: i}
+			task.ActivityID = strings.ToLower(task.ActivityID)
+			if !shared.IsValidUUID(task.ActivityID) || !isValidTaskTitle(task.Title) || !allowed[task.ActivityID] {
+				res[i].Status = constant.BULK_STATUS_INVALID
+				continue
+			}
+
+			if u.quota.MaxTasksPerActivity > 0 {
+				if remaining[task.ActivityID] <= 0 {
+					res[i].Status = constant.BULK_STATUS_INVALID
+					continue
+				}
+
+				remaining[task.ActivityID]--
+			}
+
+			valid = append(valid, task)
+			indexes = append(indexes, i)
+		}
+
+		if len(valid) == 0 {
+			return nil
+		}
+
+		var err error
+		results, err = u.taskRepository.BulkCreate(ctx, valid)
+
+		return err
+	})
+	if err != nil {
+		return res, err
+	}
+
+	if len(valid) == 0 {
+		return res, nil
+	}
+
+	countBulkSuccess(tasksCreated, sourceBulk, results)
+
+	return mergeBulkResults(res, indexes, results), nil
+}
+
+func (u TaskUseCase) BulkDeleteTask(ctx context.Context, req entity.BulkTaskRequest) ([]entity.BulkTaskResult, error) {
+	ctx, span := tracer.Start(ctx, "TaskUseCase.BulkDeleteTask")
+	defer span.End()
+
+	if !shared.IsValidUUID(req.ActivityID) {
+		return nil, fmt.Errorf("data not found")
+	}
+
+	res, valid, indexes := splitBulkIDs(req.IDs)
+	if len(valid) == 0 {
+		return res, nil
+	}
+
+	results, err := u.taskRepository.BulkDelete(ctx, entity.BulkTaskRequest{ActivityID: strings.ToLower(req.ActivityID), IDs: valid})
+	if err != nil {
+		return res, err
+	}
+
+	return mergeBulkResults(res, indexes, results), nil
+}
+
+func (u TaskUseCase) BulkCompleteTask(ctx context.Context, req entity.BulkTaskRequest) ([]entity.BulkTaskResult, error) {
+	ctx, span := tracer.Start(ctx, "TaskUseCase.BulkCompleteTask")
+	defer span.End()
+
+	if !shared.IsValidUUID(req.ActivityID) {
+		return nil, fmt.Errorf("data not found")
+	}
+
+	res, valid, indexes := splitBulkIDs(req.IDs)
+	if len(valid) == 0 {
+		return res, nil
+	}
+
+	results, err := u.taskRepository.BulkComplete(ctx, entity.BulkTaskRequest{ActivityID: strings.ToLower(req.ActivityID), IDs: valid})
+	if err != nil {
+		return res, err
+	}
+
+	countBulkSuccess(tasksCompleted, sourceBulk, results)
+
+	return mergeBulkResults(res, indexes, results), nil
+}
+
+func (u TaskUseCase) BulkMoveTask(ctx context.Context, req entity.BulkMoveTaskRequest) ([]entity.BulkTaskResult, error) {
+	ctx, span := tracer.Start(ctx, "TaskUseCase.BulkMoveTask")
+	defer span.End()
+
+	if !shared.IsValidUUID(req.ActivityID) || !shared.IsValidUUID(req.TargetActivityID) {
+		return nil, fmt.Errorf("data not found")
+	}
+
+	res, valid, indexes := splitBulkIDs(req.IDs)
+	if len(valid) == 0 {
+		return res, nil
+	}
+
+	err := checkActivityChild(ctx, u.activityRepository, strings.ToLower(req.TargetActivityID), constant.ENTITY_TASK)
+	if errors.Is(err, sql.ErrNoRows) {
+		return res, fmt.Errorf("data not found")
+	}
+
+	if err != nil {
+		return res, err
+	}
+
+	var results []entity.BulkTaskResult
+	err = u.transactor.WithTx(ctx, func(ctx context.Context) error {
+		if !strings.EqualFold(req.ActivityID, req.TargetActivityID) {
+			err := checkTaskQuota(ctx, u.taskRepository, u.quota, strings.ToLower(req.TargetActivityID), len(valid))
+			if err != nil {
+				return err
+			}
+		}
+
+		var err error
+		results, err = u.taskRepository.BulkMove(ctx, entity.BulkMoveTaskRequest{
+			ActivityID:       strings.ToLower(req.ActivityID),
+			IDs:              valid,
+			TargetActivityID: strings.ToLower(req.TargetActivityID),
+		})
+
+		return err
+	})
+	if err != nil {
+		return res, err
+	}
+
+	return mergeBulkResults(res, indexes, results), nil
+}
+
+// isValidTaskTitle reports whether the title fits the title column, so one
+// task cannot fail the insert of a whole batch.
+func isValidTaskTitle(title string) bool {
+	return title != "" && utf8.RuneCountInString(title) <= constant.MAX_TITLE_LENGTH
+}
+
+// splitBulkIDs marks malformed IDs as invalid and returns the remaining IDs
+// together with their position in the original request.
+func splitBulkIDs(ids []string) ([]entity.BulkTaskResult, []string, []int) {
+	var (
+		valid   []string
+		indexes []int
+	)
+
+	res := make([]entity.BulkTaskResult, len(ids))
+	for i, id := range ids {
+		res[i] = entity.BulkTaskResult{Index: i, ID: id}
+		if !shared.IsValidUUID(id) {
+			res[i].Status = constant.BULK_STATUS_INVALID
+			continue
+		}
+
+		valid = append(valid, strings.ToLower(id))
+		indexes = append(indexes, i)
+	}
+
+	return res, valid, indexes
+}
+
+func mergeBulkResults(res []entity.BulkTaskResult, indexes []int, results []entity.BulkTaskResult) []entity.BulkTaskResult {
+	for i, result := range results {
+		result.Index = indexes[i]
+		res[indexes[i]] = result
+	}
+
+	return res
+}
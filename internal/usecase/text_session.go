@@ -0,0 +1,417 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/digisata/todo-service/internal/constant"
+	"github.com/digisata/todo-service/internal/entity"
+	"github.com/digisata/todo-service/pkg/constans"
+	"github.com/digisata/todo-service/pkg/logging"
+	"github.com/digisata/todo-service/pkg/ot"
+	"github.com/digisata/todo-service/pkg/richtext"
+)
+
+const textSaveTimeout = 10 * time.Second
+
+// textSessionHub keeps the collaborative editing sessions of this process, so
+// every editor of a note has to be connected to the same instance.
+type textSessionHub struct {
+	mu       sync.Mutex
+	sessions map[string]*textSession
+	nextID   int64
+}
+
+type textSession struct {
+	mu            sync.Mutex
+	textID        string
+	document      *ot.Document
+	editors       map[int64]chan entity.TextEdit
+	savedRevision int
+
+	// err is set when the note could not be loaded, for editors that were
+	// waiting on it; closed once the session no longer accepts editors.
+	err    error
+	closed bool
+
+	// saveMu keeps checkpoints in order so an older document never overwrites
+	// a newer one.
+	saveMu    sync.Mutex
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func newTextSessionHub() *textSessionHub {
+	return &textSessionHub{
+		sessions: make(map[string]*textSession),
+	}
+}
+
+// JoinTextSession registers an editor for a note and returns the document it
+// has to start from.
+func (u TextUseCase) JoinTextSession(ctx context.Context, textID string) (entity.TextEditor, error) {
+	ctx, span := tracer.Start(ctx, "TextUseCase.JoinTextSession")
+	defer span.End()
+
+	var res entity.TextEditor
+
+	session, editorID, err := u.lockTextSession(ctx, textID)
+	if err != nil {
+		return res, err
+	}
+	defer session.mu.Unlock()
+
+	edits := make(chan entity.TextEdit, constant.TEXT_EDITOR_BUFFER)
+	session.editors[editorID] = edits
+
+	res = entity.TextEditor{
+		ID:       editorID,
+		TextID:   textID,
+		Revision: session.document.Revision(),
+		Text:     session.document.Text(),
+		Edits:    edits,
+	}
+
+	return res, nil
+}
+
+// lockTextSession returns the open session of a note with session.mu held,
+// along with a new editor ID. The note is loaded from the database when nobody
+// is editing it yet; only the session's own lock is held meanwhile, so editors
+// of other notes are not kept waiting.
+func (u TextUseCase) lockTextSession(ctx context.Context, textID string) (*textSession, int64, error) {
+	for {
+		u.sessions.mu.Lock()
+		u.sessions.nextID++
+		editorID := u.sessions.nextID
+
+		session, ok := u.sessions.sessions[textID]
+		if ok {
+			u.sessions.mu.Unlock()
+			session.mu.Lock()
+
+			if session.err != nil {
+				session.mu.Unlock()
+				return nil, 0, session.err
+			}
+
+			if session.closed {
+				// The last editor left meanwhile, start a new session.
+				session.mu.Unlock()
+				continue
+			}
+
+			return session, editorID, nil
+		}
+
+		session = &textSession{
+			textID:  textID,
+			editors: make(map[int64]chan entity.TextEdit),
+			done:    make(chan struct{}),
+		}
+		u.sessions.sessions[textID] = session
+
+		// Whoever joins next waits on the session lock until the note is loaded.
+		session.mu.Lock()
+		u.sessions.mu.Unlock()
+
+		text, err := u.textRepository.GetByID(ctx, textID)
+		if err != nil {
+			session.err = err
+			session.mu.Unlock()
+
+			u.sessions.mu.Lock()
+			if u.sessions.sessions[textID] == session {
+				delete(u.sessions.sessions, textID)
+			}
+			u.sessions.mu.Unlock()
+
+			return nil, 0, err
+		}
+
+		session.document = ot.NewDocument(text.Text)
+		go u.runTextSession(session)
+
+		return session, editorID, nil
+	}
+}
+
+// SubmitTextEdit merges an edit into the shared document, forwards the merged
+// operation to the other editors and acknowledges it to the sender.
+func (u TextUseCase) SubmitTextEdit(ctx context.Context, editor entity.TextEditor, req entity.TextEdit) error {
+	ctx, span := tracer.Start(ctx, "TextUseCase.SubmitTextEdit")
+	defer span.End()
+
+	u.sessions.mu.Lock()
+	session, ok := u.sessions.sessions[editor.TextID]
+	u.sessions.mu.Unlock()
+
+	if !ok {
+		return entity.ErrTextSessionClosed
+	}
+
+	session.mu.Lock()
+	defer session.mu.Unlock()
+
+	if _, ok := session.editors[editor.ID]; !ok {
+		return entity.ErrTextSessionClosed
+	}
+
+	err := checkEditSize(u.quota, session.document.Text(), toOperation(req.Operation))
+	if err != nil {
+		return err
+	}
+
+	op, err := session.document.Apply(req.Revision, toOperation(req.Operation))
+	if errors.Is(err, ot.ErrRevisionTooOld) {
+		return fmt.Errorf("%w: %v", entity.ErrTextSessionClosed, err)
+	}
+
+	if err != nil {
+		return fmt.Errorf("%w: %v", entity.ErrInvalidTextEdit, err)
+	}
+
+	session.broadcast(editor.ID, entity.TextEdit{
+		Revision:  session.document.Revision(),
+		Operation: fromOperation(op),
+	})
+	session.send(editor.ID, entity.TextEdit{
+		Revision: session.document.Revision(),
+		Ack:      true,
+	})
+
+	return nil
+}
+
+// LeaveTextSession unregisters an editor. The last editor to leave writes the
+// final document and closes the session. The session stays registered while
+// it saves, so an editor joining meanwhile continues it instead of loading
+// the note before it is saved.
+func (u TextUseCase) LeaveTextSession(ctx context.Context, editor entity.TextEditor) error {
+	ctx, span := tracer.Start(ctx, "TextUseCase.LeaveTextSession")
+	defer span.End()
+
+	// The editor's stream is usually gone by now, the final save must not be.
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), textSaveTimeout)
+	defer cancel()
+
+	u.sessions.mu.Lock()
+	session, ok := u.sessions.sessions[editor.TextID]
+	u.sessions.mu.Unlock()
+
+	if !ok {
+		return nil
+	}
+
+	session.mu.Lock()
+	if edits, ok := session.editors[editor.ID]; ok {
+		close(edits)
+		delete(session.editors, editor.ID)
+	}
+	empty := len(session.editors) == 0
+	session.mu.Unlock()
+
+	if !empty {
+		return nil
+	}
+
+	err := u.checkpointTextSession(ctx, session)
+
+	u.sessions.mu.Lock()
+	defer u.sessions.mu.Unlock()
+
+	if u.sessions.sessions[editor.TextID] != session {
+		return err
+	}
+
+	// An editor that joined and left meanwhile may still be saving its edits;
+	// its own leave closes the session then. A failed save closes it anyway.
+	session.mu.Lock()
+	done := len(session.editors) == 0 && (err != nil || session.document.Revision() == session.savedRevision)
+	session.mu.Unlock()
+
+	if done {
+		delete(u.sessions.sessions, editor.TextID)
+		session.close()
+	}
+
+	return err
+}
+
+// textSessionOpen reports whether the note is being edited collaboratively.
+func (u TextUseCase) textSessionOpen(textID string) bool {
+	u.sessions.mu.Lock()
+	defer u.sessions.mu.Unlock()
+
+	_, ok := u.sessions.sessions[textID]
+
+	return ok
+}
+
+func (u TextUseCase) runTextSession(session *textSession) {
+	ticker := time.NewTicker(constant.TEXT_CHECKPOINT_INTERVAL)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-session.done:
+			return
+		case <-ticker.C:
+			ctx := context.Background()
+
+			err := u.checkpointTextSession(ctx, session)
+			if err != nil && err.Error() != "data not found" {
+				// Editors keep the document, the next checkpoint retries.
+				logging.FromContext(ctx).Warnw(constans.WARN,
+					"message", "text checkpoint failed",
+					"text_id", session.textID,
+					"error", err.Error(),
+				)
+			}
+
+			if err != nil && err.Error() == "data not found" {
+				// The note was deleted, nothing left to edit.
+				u.sessions.mu.Lock()
+				if u.sessions.sessions[session.textID] == session {
+					delete(u.sessions.sessions, session.textID)
+				}
+				u.sessions.mu.Unlock()
+
+				session.close()
+				return
+			}
+		}
+	}
+}
+
+// checkpointTextSession stores the shared document when it changed since the
+// last checkpoint. The document is sanitized here and not on every edit, which
+// would take a pass over the whole note per keystroke and rewrite markup other
+// editors are still typing; every editor gets the cleanup, so they keep the
+// same text as the database.
+func (u TextUseCase) checkpointTextSession(ctx context.Context, session *textSession) error {
+	session.saveMu.Lock()
+	defer session.saveMu.Unlock()
+
+	session.mu.Lock()
+	if session.document.Revision() == session.savedRevision {
+		session.mu.Unlock()
+		return nil
+	}
+
+	cleanup, err := session.sanitize()
+	if err != nil {
+		session.mu.Unlock()
+		return err
+	}
+
+	if cleanup != nil {
+		session.broadcast(0, entity.TextEdit{
+			Revision:  session.document.Revision(),
+			Operation: fromOperation(cleanup),
+		})
+	}
+
+	content := session.document.Text()
+	plainText := richtext.PlainText(content)
+	revision := session.document.Revision()
+	session.mu.Unlock()
+
+	err = u.textRepository.Update(ctx, entity.UpdateTextRequest{
+		ID:        session.textID,
+		Text:      &content,
+		PlainText: &plainText,
+	})
+	if err != nil {
+		return err
+	}
+
+	session.mu.Lock()
+	session.savedRevision = revision
+	session.mu.Unlock()
+
+	return nil
+}
+
+// sanitize removes the markup the sanitizer drops from the shared document
+// and returns the operation that did it, or nil when the document was already
+// clean. Callers must hold session.mu.
+func (s *textSession) sanitize() (ot.Operation, error) {
+	text := s.document.Text()
+
+	content := richtext.Sanitize(text)
+	if content == text {
+		return nil, nil
+	}
+
+	return s.document.Apply(s.document.Revision(), ot.FromDiff(text, content))
+}
+
+// broadcast sends an edit to every editor except the one with the given ID.
+// Callers must hold session.mu.
+func (s *textSession) broadcast(from int64, edit entity.TextEdit) {
+	for id := range s.editors {
+		if id != from {
+			s.send(id, edit)
+		}
+	}
+}
+
+// send delivers an edit without blocking the session. An editor that fell too
+// far behind is dropped and has to join again. Callers must hold session.mu.
+func (s *textSession) send(id int64, edit entity.TextEdit) {
+	edits, ok := s.editors[id]
+	if !ok {
+		return
+	}
+
+	select {
+	case edits <- edit:
+	default:
+		close(edits)
+		delete(s.editors, id)
+	}
+}
+
+func (s *textSession) close() {
+	s.mu.Lock()
+	s.closed = true
+	for id, edits := range s.editors {
+		close(edits)
+		delete(s.editors, id)
+	}
+	s.mu.Unlock()
+
+	s.closeOnce.Do(func() {
+		close(s.done)
+	})
+}
+
+func toOperation(components []entity.TextOperationComponent) ot.Operation {
+	op := make(ot.Operation, 0, len(components))
+	for _, c := range components {
+		op = append(op, ot.Component{
+			Retain: c.Retain,
+			Insert: c.Insert,
+			Delete: c.Delete,
+		})
+	}
+
+	return op
+}
+
+func fromOperation(op ot.Operation) []entity.TextOperationComponent {
+	components := make([]entity.TextOperationComponent, 0, len(op))
+	for _, c := range op {
+		components = append(components, entity.TextOperationComponent{
+			Retain: c.Retain,
+			Insert: c.Insert,
+			Delete: c.Delete,
+		})
+	}
+
+	return components
+}
@@ -3,20 +3,51 @@ package usecase
 import (
 	"context"
 
+	"github.com/digisata/todo-service/internal/constant"
 	"github.com/digisata/todo-service/internal/entity"
 	"github.com/digisata/todo-service/internal/shared"
+	"github.com/digisata/todo-service/pkg/richtext"
+	"github.com/sergi/go-diff/diffmatchpatch"
 )
 
+const sanitizeBatchSize = 100
+
 type TextUseCase struct {
-	textRepository TextRepository
+	textRepository     TextRepository
+	activityRepository ActivityRepository
+	sessions           *textSessionHub
+	quota              entity.Quota
 }
 
-func NewText(textRepository TextRepository) *TextUseCase {
-	return &TextUseCase{textRepository: textRepository}
+func NewText(textRepository TextRepository, activityRepository ActivityRepository, quota entity.Quota) *TextUseCase {
+	return &TextUseCase{
+		textRepository:     textRepository,
+		activityRepository: activityRepository,
+		sessions:           newTextSessionHub(),
+		quota:              quota,
+	}
 }
 
 func (u TextUseCase) CreateText(ctx context.Context, req entity.CreateTextRequest) error {
-	err := u.textRepository.Create(ctx, req)
+	ctx, span := tracer.Start(ctx, "TextUseCase.CreateText")
+	defer span.End()
+
+	err := checkActivityChild(ctx, u.activityRepository, req.ActivityID, constant.ENTITY_TEXT)
+	if err != nil {
+		return err
+	}
+
+	req.Text, req.PlainText, err = renderText(req.Text, req.Format)
+	if err != nil {
+		return err
+	}
+
+	err = checkNoteSize(u.quota, req.Text)
+	if err != nil {
+		return err
+	}
+
+	err = u.textRepository.Create(ctx, req)
 	if err != nil {
 		return err
 	}
@@ -25,6 +56,29 @@ func (u TextUseCase) CreateText(ctx context.Context, req entity.CreateTextReques
 }
 
 func (u TextUseCase) UpdateText(ctx context.Context, req entity.UpdateTextRequest) error {
+	ctx, span := tracer.Start(ctx, "TextUseCase.UpdateText")
+	defer span.End()
+
+	// The session would overwrite the new text at its next checkpoint.
+	if req.Text != nil && u.textSessionOpen(req.ID) {
+		return entity.ErrTextSessionOpen
+	}
+
+	if req.Text != nil {
+		content, plainText, err := renderText(*req.Text, req.Format)
+		if err != nil {
+			return err
+		}
+
+		err = checkNoteSize(u.quota, content)
+		if err != nil {
+			return err
+		}
+
+		req.Text = &content
+		req.PlainText = &plainText
+	}
+
 	err := u.textRepository.Update(ctx, req)
 	if err != nil {
 		return err
@@ -45,6 +99,9 @@ func (u TextUseCase) UpdateText(ctx context.Context, req entity.UpdateTextReques
 // }
 
 func (u TextUseCase) GetText(ctx context.Context, id string) (entity.Text, error) {
+	ctx, span := tracer.Start(ctx, "TextUseCase.GetText")
+	defer span.End()
+
 	var res entity.Text
 	res, err := u.textRepository.GetByID(ctx, id)
 	if err != nil {
@@ -58,6 +115,9 @@ func (u TextUseCase) GetText(ctx context.Context, id string) (entity.Text, error
 }
 
 func (u TextUseCase) GetAllTextByActivityID(ctx context.Context, req entity.GetAllTextRequest) ([]entity.Text, entity.Paging, error) {
+	ctx, span := tracer.Start(ctx, "TextUseCase.GetAllTextByActivityID")
+	defer span.End()
+
 	var (
 		res    []entity.Text
 		paging entity.Paging
@@ -76,6 +136,9 @@ func (u TextUseCase) GetAllTextByActivityID(ctx context.Context, req entity.GetA
 }
 
 func (u TextUseCase) DeleteText(ctx context.Context, id string) error {
+	ctx, span := tracer.Start(ctx, "TextUseCase.DeleteText")
+	defer span.End()
+
 	err := u.textRepository.Delete(ctx, id)
 	if err != nil {
 		return err
@@ -83,3 +146,182 @@ func (u TextUseCase) DeleteText(ctx context.Context, id string) error {
 
 	return nil
 }
+
+func (u TextUseCase) GetAllTextRevision(ctx context.Context, req entity.GetAllTextRevisionRequest) ([]entity.TextRevision, entity.Paging, error) {
+	ctx, span := tracer.Start(ctx, "TextUseCase.GetAllTextRevision")
+	defer span.End()
+
+	res, paging, err := u.textRepository.GetAllRevision(ctx, req)
+	if err != nil {
+		return res, paging, err
+	}
+
+	for i := 0; i < len(res); i++ {
+		res[i].CreatedAt = shared.ConvertToJakartaTime(res[i].CreatedAt)
+	}
+
+	return res, paging, nil
+}
+
+func (u TextUseCase) GetTextRevision(ctx context.Context, req entity.GetTextRevisionRequest) (entity.TextRevision, error) {
+	ctx, span := tracer.Start(ctx, "TextUseCase.GetTextRevision")
+	defer span.End()
+
+	res, err := u.textRepository.GetRevision(ctx, req)
+	if err != nil {
+		return res, err
+	}
+
+	res.CreatedAt = shared.ConvertToJakartaTime(res.CreatedAt)
+
+	return res, nil
+}
+
+// DiffTextRevision compares the plain text of two revisions line by line.
+// Without ToRevision the current content of the note is used.
+func (u TextUseCase) DiffTextRevision(ctx context.Context, req entity.DiffTextRevisionRequest) ([]entity.TextDiff, error) {
+	ctx, span := tracer.Start(ctx, "TextUseCase.DiffTextRevision")
+	defer span.End()
+
+	var res []entity.TextDiff
+
+	from, err := u.textRepository.GetRevision(ctx, entity.GetTextRevisionRequest{
+		TextID:   req.TextID,
+		Revision: req.FromRevision,
+	})
+	if err != nil {
+		return res, err
+	}
+
+	var to string
+	if req.ToRevision != nil {
+		revision, err := u.textRepository.GetRevision(ctx, entity.GetTextRevisionRequest{
+			TextID:   req.TextID,
+			Revision: *req.ToRevision,
+		})
+		if err != nil {
+			return res, err
+		}
+
+		to = revision.PlainText
+	} else {
+		text, err := u.textRepository.GetByID(ctx, req.TextID)
+		if err != nil {
+			return res, err
+		}
+
+		to = text.PlainText
+	}
+
+	dmp := diffmatchpatch.New()
+	fromChars, toChars, lines := dmp.DiffLinesToChars(from.PlainText, to)
+	diffs := dmp.DiffCharsToLines(dmp.DiffMain(fromChars, toChars, false), lines)
+	diffs = dmp.DiffCleanupSemantic(diffs)
+
+	for _, diff := range diffs {
+		operation := constant.TEXT_DIFF_EQUAL
+		switch diff.Type {
+		case diffmatchpatch.DiffInsert:
+			operation = constant.TEXT_DIFF_INSERT
+		case diffmatchpatch.DiffDelete:
+			operation = constant.TEXT_DIFF_DELETE
+		}
+
+		res = append(res, entity.TextDiff{
+			Operation: operation,
+			Text:      diff.Text,
+		})
+	}
+
+	return res, nil
+}
+
+func (u TextUseCase) RestoreTextRevision(ctx context.Context, req entity.GetTextRevisionRequest) error {
+	ctx, span := tracer.Start(ctx, "TextUseCase.RestoreTextRevision")
+	defer span.End()
+
+	if u.textSessionOpen(req.TextID) {
+		return entity.ErrTextSessionOpen
+	}
+
+	err := u.textRepository.RestoreRevision(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// SanitizeStoredTexts re-renders the notes and revisions stored before the
+// sanitizer was added. It runs once at startup, before anything is served,
+// and has nothing left to do afterwards.
+func (u TextUseCase) SanitizeStoredTexts(ctx context.Context) error {
+	ctx, span := tracer.Start(ctx, "TextUseCase.SanitizeStoredTexts")
+	defer span.End()
+
+	for {
+		texts, err := u.textRepository.GetUnsanitized(ctx, sanitizeBatchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, text := range texts {
+			text.Text, text.PlainText, err = renderText(text.Text, constant.TEXT_FORMAT_HTML)
+			if err != nil {
+				return err
+			}
+
+			err = u.textRepository.UpdateSanitized(ctx, text)
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(texts) < sanitizeBatchSize {
+			break
+		}
+	}
+
+	for {
+		revisions, err := u.textRepository.GetUnsanitizedRevisions(ctx, sanitizeBatchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, revision := range revisions {
+			revision.Text, revision.PlainText, err = renderText(revision.Text, constant.TEXT_FORMAT_HTML)
+			if err != nil {
+				return err
+			}
+
+			err = u.textRepository.UpdateSanitizedRevision(ctx, revision)
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(revisions) < sanitizeBatchSize {
+			return nil
+		}
+	}
+}
+
+// renderText turns client input into sanitized HTML and its plain-text
+// projection. Markdown is converted to HTML first.
+func renderText(text, format string) (string, string, error) {
+	var err error
+
+	switch format {
+	case "", constant.TEXT_FORMAT_HTML:
+		text = richtext.Sanitize(text)
+	case constant.TEXT_FORMAT_MARKDOWN:
+		text, err = richtext.FromMarkdown(text)
+		if err != nil {
+			return "", "", err
+		}
+	default:
+		return "", "", entity.ErrInvalidTextFormat
+	}
+
+	return text, richtext.PlainText(text), nil
+}
@@ -0,0 +1,523 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/digisata/todo-service/internal/constant"
+	"github.com/digisata/todo-service/internal/entity"
+	"github.com/digisata/todo-service/internal/shared"
+	"github.com/digisata/todo-service/pkg/exporter"
+	"github.com/digisata/todo-service/pkg/importer"
+)
+
+type TransferUseCase struct {
+	activityRepository ActivityRepository
+	taskRepository     TaskRepository
+	textRepository     TextRepository
+	transactor         Transactor
+	quota              entity.Quota
+}
+
+func NewTransfer(activityRepository ActivityRepository, taskRepository TaskRepository, textRepository TextRepository, transactor Transactor, quota entity.Quota) *TransferUseCase {
+	return &TransferUseCase{
+		activityRepository: activityRepository,
+		taskRepository:     taskRepository,
+		textRepository:     textRepository,
+		transactor:         transactor,
+		quota:              quota,
+	}
+}
+
+// importPlan is what one imported list turns into.
+type importPlan struct {
+	activity entity.CreateActivityRequest
+	tasks    []entity.CreateTaskRequest
+	texts    []entity.CreateTextRequest
+}
+
+// Import turns every list of the file into a task activity. Task notes go to
+// a journal activity next to it, one text per task, because task lists do not
+// hold texts. Files exported by this service keep their activity types.
+// Everything is written in one transaction; on a dry run nothing is written
+// and the result tells what would have been created.
+func (u TransferUseCase) Import(ctx context.Context, req entity.ImportRequest, r io.Reader) (entity.ImportResult, error) {
+	ctx, span := tracer.Start(ctx, "TransferUseCase.Import")
+	defer span.End()
+
+	res := entity.ImportResult{DryRun: req.DryRun}
+
+	data, err := io.ReadAll(io.LimitReader(r, constant.MAX_IMPORT_SIZE+1))
+	if err != nil {
+		return res, err
+	}
+
+	if int64(len(data)) > constant.MAX_IMPORT_SIZE {
+		return res, fmt.Errorf("%w: limit is %d bytes", entity.ErrImportTooLarge, constant.MAX_IMPORT_SIZE)
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		name = constant.DEFAULT_IMPORT_NAME
+	}
+
+	lists, err := importer.Parse(req.Format, name, bytes.NewReader(data))
+	if errors.Is(err, importer.ErrUnsupportedFormat) || errors.Is(err, importer.ErrMalformed) {
+		return res, fmt.Errorf("%w: %v", entity.ErrInvalidImport, err)
+	}
+
+	if err != nil {
+		return res, err
+	}
+
+	if len(lists) == 0 {
+		return res, fmt.Errorf("%w: no tasks found", entity.ErrInvalidImport)
+	}
+
+	plans, err := planImport(lists)
+	if err != nil {
+		return res, err
+	}
+
+	for _, plan := range plans {
+		imported := entity.ImportedActivity{
+			Title: plan.activity.Title,
+			Type:  plan.activity.Type,
+			Tasks: len(plan.tasks),
+			Texts: len(plan.texts),
+		}
+
+		for _, task := range plan.tasks {
+			if !shared.Deref(task.IsActive) {
+				imported.CompletedTasks++
+			}
+		}
+
+		res.Activities = append(res.Activities, imported)
+		res.Tasks += imported.Tasks
+		res.Texts += imported.Texts
+	}
+
+	// A dry run reports quota violations too
+	if req.DryRun {
+		err = u.checkImportQuota(ctx, plans)
+		if err != nil {
+			return res, err
+		}
+
+		return res, nil
+	}
+
+	err = u.transactor.WithTx(ctx, func(ctx context.Context) error {
+		err := u.checkImportQuota(ctx, plans)
+		if err != nil {
+			return err
+		}
+
+		for i, plan := range plans {
+			plan.activity.UserID = requestUserID(ctx)
+			activityID, err := u.activityRepository.Create(ctx, plan.activity)
+			if err != nil {
+				return err
+			}
+
+			for _, task := range plan.tasks {
+				task.ActivityID = activityID
+				err = u.taskRepository.Create(ctx, task)
+				if err != nil {
+					return err
+				}
+			}
+
+			for _, text := range plan.texts {
+				text.ActivityID = activityID
+				err = u.textRepository.Create(ctx, text)
+				if err != nil {
+					return err
+				}
+			}
+
+			res.Activities[i].ID = activityID
+		}
+
+		return nil
+	})
+	if err != nil {
+		return entity.ImportResult{DryRun: req.DryRun}, err
+	}
+
+	for _, plan := range plans {
+		for _, task := range plan.tasks {
+			tasksCreated.WithLabelValues(sourceImport).Inc()
+			if isCompleting(task.IsActive) {
+				tasksCompleted.WithLabelValues(sourceImport).Inc()
+			}
+		}
+	}
+
+	return res, nil
+}
+
+// checkImportQuota checks the whole import up front so it is not rolled back
+// halfway through. Outside a dry run it runs in the import transaction.
+func (u TransferUseCase) checkImportQuota(ctx context.Context, plans []importPlan) error {
+	err := checkActivityQuota(ctx, u.activityRepository, u.quota, len(plans))
+	if err != nil {
+		return err
+	}
+
+	for _, plan := range plans {
+		err = checkTaskCount(u.quota, len(plan.tasks))
+		if err != nil {
+			return err
+		}
+
+		for _, text := range plan.texts {
+			err = checkNoteSize(u.quota, text.Text)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func planImport(lists []importer.List) ([]importPlan, error) {
+	taskType, err := resolveActivityType(constant.ACTIVITY_TYPE_TASK, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	journalType, err := resolveActivityType(constant.ACTIVITY_TYPE_JOURNAL, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var plans []importPlan
+	for _, list := range lists {
+		if list.Type != "" {
+			plan, err := planTypedImport(list)
+			if err != nil {
+				return nil, err
+			}
+
+			plans = append(plans, plan)
+			continue
+		}
+
+		tasks := importPlan{
+			activity: entity.CreateActivityRequest{Title: shared.TruncateTitle(list.Title, constant.MAX_TITLE_LENGTH), Type: taskType.Name},
+		}
+		notes := importPlan{
+			activity: entity.CreateActivityRequest{Title: shared.TruncateTitle(list.Title, constant.MAX_TITLE_LENGTH-len(" notes")) + " notes", Type: journalType.Name},
+		}
+
+		for _, task := range list.Tasks {
+			tasks.tasks = append(tasks.tasks, importedTask(task))
+
+			// Keep a note for long titles too, so nothing is lost to truncation.
+			if task.Notes == "" && utf8.RuneCountInString(task.Title) <= constant.MAX_TITLE_LENGTH {
+				continue
+			}
+
+			content, plainText, err := renderText(importedNote(task), constant.TEXT_FORMAT_HTML)
+			if err != nil {
+				return nil, err
+			}
+
+			notes.texts = append(notes.texts, entity.CreateTextRequest{
+				Text:      content,
+				PlainText: plainText,
+			})
+		}
+
+		err = shared.ValidateActivityTitle(tasks.activity.Title)
+		if err != nil {
+			return nil, err
+		}
+
+		plans = append(plans, tasks)
+
+		if len(notes.texts) > 0 {
+			err = shared.ValidateActivityTitle(notes.activity.Title)
+			if err != nil {
+				return nil, err
+			}
+
+			plans = append(plans, notes)
+		}
+	}
+
+	return plans, nil
+}
+
+// planTypedImport keeps a list exported by this service as one activity of
+// its original type.
+func planTypedImport(list importer.List) (importPlan, error) {
+	plan := importPlan{
+		activity: entity.CreateActivityRequest{Title: shared.TruncateTitle(list.Title, constant.MAX_TITLE_LENGTH), Type: list.Type},
+	}
+
+	activityType, err := resolveActivityType(list.Type, 0)
+	if err != nil {
+		return plan, fmt.Errorf("%w: %q: %v", entity.ErrInvalidImport, list.Title, err)
+	}
+
+	err = shared.ValidateActivityTitle(plan.activity.Title)
+	if err != nil {
+		return plan, err
+	}
+
+	if len(list.Tasks) > 0 {
+		err = checkActivityTypeChild(activityType.Name, constant.ENTITY_TASK)
+	}
+
+	if err == nil && len(list.Texts) > 0 {
+		err = checkActivityTypeChild(activityType.Name, constant.ENTITY_TEXT)
+	}
+
+	if err != nil {
+		return plan, fmt.Errorf("%w: %q: %v", entity.ErrInvalidImport, list.Title, err)
+	}
+
+	for _, task := range list.Tasks {
+		plan.tasks = append(plan.tasks, importedTask(task))
+	}
+
+	for _, text := range list.Texts {
+		content, plainText, err := renderText(text, constant.TEXT_FORMAT_HTML)
+		if err != nil {
+			return plan, err
+		}
+
+		plan.texts = append(plan.texts, entity.CreateTextRequest{
+			Text:      content,
+			PlainText: plainText,
+		})
+	}
+
+	return plan, nil
+}
+
+func importedTask(task importer.Task) entity.CreateTaskRequest {
+	isActive := !task.Completed
+
+	return entity.CreateTaskRequest{
+		Title:    shared.TruncateTitle(task.Title, constant.MAX_TITLE_LENGTH),
+		IsActive: &isActive,
+		Priority: task.Priority,
+		DueAt:    task.DueAt,
+	}
+}
+
+// importedNote renders the notes of a task as HTML headed by its title.
+func importedNote(task importer.Task) string {
+	var b strings.Builder
+
+	b.WriteString("<h3>" + html.EscapeString(task.Title) + "</h3>")
+	for _, line := range strings.Split(task.Notes, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" {
+			b.WriteString("<p>" + html.EscapeString(line) + "</p>")
+		}
+	}
+
+	return b.String()
+}
+
+// ExportFile checks the format of req and names the file Export writes.
+func (u TransferUseCase) ExportFile(req entity.ExportRequest) (entity.ExportFile, error) {
+	extension, contentType, err := exporter.File(req.Format)
+	if err != nil {
+		return entity.ExportFile{}, fmt.Errorf("%w: %v", entity.ErrInvalidExport, err)
+	}
+
+	return entity.ExportFile{
+		Name:        fmt.Sprintf("todo-export-%s.%s", time.Now().UTC().Format("20060102"), extension),
+		ContentType: contentType,
+	}, nil
+}
+
+// Export writes one or all activities of the caller with their tasks and
+// texts to w. Rows are read a page at a time, so memory use does not grow with
+// the account. Times are written in UTC.
+func (u TransferUseCase) Export(ctx context.Context, req entity.ExportRequest, w io.Writer) error {
+	ctx, span := tracer.Start(ctx, "TransferUseCase.Export")
+	defer span.End()
+
+	writer, err := exporter.NewWriter(req.Format, w)
+	if err != nil {
+		return fmt.Errorf("%w: %v", entity.ErrInvalidExport, err)
+	}
+
+	userID := requestUserID(ctx)
+
+	if req.ActivityID != "" {
+		activity, err := u.activityRepository.GetByID(ctx, req.ActivityID)
+		if err != nil {
+			return err
+		}
+
+		if shared.Deref(activity.UserID) != shared.Deref(userID) {
+			return fmt.Errorf("%w: the activity belongs to another user", entity.ErrForbidden)
+		}
+
+		err = u.exportActivity(ctx, writer, activity, req.IncludeArchived)
+		if err != nil {
+			return err
+		}
+
+		return writer.Close()
+	}
+
+	// Without a user GetAll would list every account
+	if userID == nil {
+		return fmt.Errorf("%w: exporting every activity needs a caller", entity.ErrForbidden)
+	}
+
+	err = eachPage(func(page, limit *int32) (entity.Paging, error) {
+		activities, paging, err := u.activityRepository.GetAll(ctx, entity.GetAllActivityRequest{
+			UserID:          userID,
+			Page:            page,
+			Limit:           limit,
+			IncludeArchived: &req.IncludeArchived,
+		})
+		if err != nil {
+			return paging, err
+		}
+
+		for _, activity := range activities {
+			err = u.exportActivity(ctx, writer, activity, req.IncludeArchived)
+			if err != nil {
+				return paging, err
+			}
+		}
+
+		return paging, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return writer.Close()
+}
+
+func (u TransferUseCase) exportActivity(ctx context.Context, writer exporter.Writer, activity entity.Activity, includeArchived bool) error {
+	err := writer.BeginActivity(exportedActivity(activity))
+	if err != nil {
+		return err
+	}
+
+	// Archived tasks are only listed on their own.
+	archivedStates := []bool{false}
+	if includeArchived {
+		archivedStates = append(archivedStates, true)
+	}
+
+	for _, isArchived := range archivedStates {
+		err = eachPage(func(page, limit *int32) (entity.Paging, error) {
+			tasks, paging, err := u.taskRepository.GetAll(ctx, entity.GetAllTaskRequest{
+				ActivityID: activity.ID,
+				IsArchived: &isArchived,
+				Page:       page,
+				Limit:      limit,
+			})
+			if err != nil {
+				return paging, err
+			}
+
+			for _, task := range tasks {
+				err = writer.WriteTask(exportedTask(task))
+				if err != nil {
+					return paging, err
+				}
+			}
+
+			return paging, nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	err = eachPage(func(page, limit *int32) (entity.Paging, error) {
+		texts, paging, err := u.textRepository.GetAll(ctx, entity.GetAllTextRequest{
+			ActivityID: activity.ID,
+			Page:       page,
+			Limit:      limit,
+		})
+		if err != nil {
+			return paging, err
+		}
+
+		for _, text := range texts {
+			err = writer.WriteText(exporter.Text{
+				ID:        text.ID,
+				Text:      text.Text,
+				PlainText: text.PlainText,
+				CreatedAt: text.CreatedAt,
+				UpdatedAt: text.UpdatedAt,
+			})
+			if err != nil {
+				return paging, err
+			}
+		}
+
+		return paging, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return writer.EndActivity()
+}
+
+func exportedActivity(activity entity.Activity) exporter.Activity {
+	return exporter.Activity{
+		ID:         activity.ID,
+		Title:      activity.Title,
+		Type:       activity.Type,
+		CreatedAt:  activity.CreatedAt,
+		UpdatedAt:  activity.UpdatedAt,
+		ArchivedAt: activity.ArchivedAt,
+	}
+}
+
+func exportedTask(task entity.Task) exporter.Task {
+	return exporter.Task{
+		ID:         task.ID,
+		UID:        shared.Deref(task.ICalUID),
+		Title:      task.Title,
+		Completed:  !task.IsActive,
+		Priority:   task.Priority,
+		Order:      task.Order,
+		DueAt:      task.DueAt,
+		CreatedAt:  task.CreatedAt,
+		UpdatedAt:  task.UpdatedAt,
+		ArchivedAt: task.ArchivedAt,
+	}
+}
+
+// eachPage calls fn with page 1, 2, ... until the paging it returns says the
+// last page was read.
+func eachPage(fn func(page, limit *int32) (entity.Paging, error)) error {
+	limit := constant.EXPORT_PAGE_SIZE
+	for page := int32(1); ; page++ {
+		paging, err := fn(&page, &limit)
+		if err != nil {
+			return err
+		}
+
+		if page >= paging.TotalPage {
+			return nil
+		}
+	}
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"log"
+	"os"
 
 	"github.com/digisata/todo-service/config"
 	"github.com/digisata/todo-service/internal/app"
@@ -13,5 +14,21 @@ func main() {
 		log.Fatalf("fatal error in config file: %s", err.Error())
 	}
 
-	app.Run(cfg)
+	command := ""
+	if len(os.Args) > 1 {
+		command = os.Args[1]
+	}
+
+	switch command {
+	case "import":
+		err = app.RunImport(cfg, os.Args[2:])
+	case "export":
+		err = app.RunExport(cfg, os.Args[2:])
+	default:
+		app.Run(cfg)
+	}
+
+	if err != nil {
+		log.Fatalf("%s: %s", command, err.Error())
+	}
 }
@@ -0,0 +1,47 @@
+CREATE TABLE activity_events (
+    id BIGSERIAL PRIMARY KEY,
+    activity_id UUID NOT NULL,
+    entity_type VARCHAR(20) NOT NULL,
+    entity_id UUID NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT (NOW() AT TIME ZONE 'utc'),
+    CONSTRAINT fk_activity_id
+        FOREIGN KEY(activity_id)
+        REFERENCES activities(id)
+);
+
+CREATE INDEX idx_activity_events_activity_id ON activity_events (activity_id, id);
+
+CREATE OR REPLACE FUNCTION record_activity_event() RETURNS TRIGGER AS $$
+DECLARE
+    event_action VARCHAR(20) := 'updated';
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        event_action := 'created';
+    ELSIF NEW.deleted_at IS NOT NULL AND OLD.deleted_at IS NULL THEN
+        event_action := 'deleted';
+    ELSIF TG_TABLE_NAME = 'tasks' THEN
+        IF NEW.order_position IS DISTINCT FROM OLD.order_position
+            AND NEW.title IS NOT DISTINCT FROM OLD.title
+            AND NEW.is_active IS NOT DISTINCT FROM OLD.is_active
+            AND NEW.priority IS NOT DISTINCT FROM OLD.priority THEN
+            event_action := 'reordered';
+        END IF;
+    END IF;
+
+    INSERT INTO activity_events (activity_id, entity_type, entity_id, action)
+    VALUES (NEW.activity_id, TG_ARGV[0], NEW.id, event_action);
+
+    PERFORM pg_notify('activity_events', NEW.activity_id::text);
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER tasks_activity_event
+AFTER INSERT OR UPDATE ON tasks
+FOR EACH ROW EXECUTE FUNCTION record_activity_event('task');
+
+CREATE TRIGGER texts_activity_event
+AFTER INSERT OR UPDATE ON texts
+FOR EACH ROW EXECUTE FUNCTION record_activity_event('text');
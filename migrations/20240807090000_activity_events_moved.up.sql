@@ -0,0 +1,40 @@
+CREATE OR REPLACE FUNCTION record_activity_event() RETURNS TRIGGER AS $$
+DECLARE
+    event_action VARCHAR(20) := 'updated';
+    event_activity_id UUID;
+BEGIN
+    IF TG_TABLE_NAME = 'activities' THEN
+        event_activity_id := NEW.id;
+    ELSE
+        event_activity_id := NEW.activity_id;
+    END IF;
+
+    IF TG_OP = 'INSERT' THEN
+        event_action := 'created';
+    ELSIF NEW.deleted_at IS NOT NULL AND OLD.deleted_at IS NULL THEN
+        event_action := 'deleted';
+    ELSIF TG_TABLE_NAME = 'tasks' THEN
+        IF NEW.activity_id IS DISTINCT FROM OLD.activity_id THEN
+            event_action := 'moved';
+
+            -- Let the source activity know the task left
+            INSERT INTO activity_events (activity_id, entity_type, entity_id, action)
+            VALUES (OLD.activity_id, TG_ARGV[0], NEW.id, event_action);
+
+            PERFORM pg_notify('activity_events', OLD.activity_id::text);
+        ELSIF NEW.order_position IS DISTINCT FROM OLD.order_position
+            AND NEW.title IS NOT DISTINCT FROM OLD.title
+            AND NEW.is_active IS NOT DISTINCT FROM OLD.is_active
+            AND NEW.priority IS NOT DISTINCT FROM OLD.priority THEN
+            event_action := 'reordered';
+        END IF;
+    END IF;
+
+    INSERT INTO activity_events (activity_id, entity_type, entity_id, action)
+    VALUES (event_activity_id, TG_ARGV[0], NEW.id, event_action);
+
+    PERFORM pg_notify('activity_events', event_activity_id::text);
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
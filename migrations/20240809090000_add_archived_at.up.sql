@@ -0,0 +1,5 @@
+ALTER TABLE activities
+ADD COLUMN "archived_at" TIMESTAMP;
+
+ALTER TABLE tasks
+ADD COLUMN "archived_at" TIMESTAMP;
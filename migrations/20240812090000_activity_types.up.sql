@@ -0,0 +1,23 @@
+CREATE TABLE activity_types (
+    name VARCHAR(50) PRIMARY KEY,
+    description VARCHAR(255) NOT NULL DEFAULT ''
+);
+
+INSERT INTO activity_types (name, description) VALUES
+    ('activity_task', 'List of tasks'),
+    ('activity_text', 'Single rich-text note'),
+    ('activity_checklist', 'Checklist of short items'),
+    ('activity_kanban', 'Board of prioritised tasks'),
+    ('activity_journal', 'Series of dated notes');
+
+UPDATE activities
+SET type = 'activity_task'
+WHERE type NOT IN (SELECT name FROM activity_types);
+
+ALTER TABLE activities
+ALTER COLUMN "type" SET DEFAULT 'activity_task';
+
+ALTER TABLE activities
+ADD CONSTRAINT fk_activity_type
+    FOREIGN KEY("type")
+    REFERENCES activity_types(name);
@@ -0,0 +1,6 @@
+ALTER TABLE texts
+ADD COLUMN "plain_text" TEXT NOT NULL DEFAULT '';
+
+-- Rough projection for existing notes, rewritten on their next update
+UPDATE texts
+SET plain_text = TRIM(regexp_replace(text, '<[^>]*>', ' ', 'g'));
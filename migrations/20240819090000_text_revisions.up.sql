@@ -0,0 +1,47 @@
+CREATE TABLE text_revisions (
+    text_id UUID NOT NULL,
+    revision INT NOT NULL,
+    text TEXT NOT NULL,
+    plain_text TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (text_id, revision),
+    CONSTRAINT fk_text_id
+        FOREIGN KEY(text_id)
+        REFERENCES texts(id)
+);
+
+-- Snapshot the previous content of a note whenever it changes. Rapid autosaves
+-- are coalesced into the snapshot taken at the start of the burst, except when
+-- the note is cleared or a snapshot is forced with todo.force_text_revision.
+CREATE OR REPLACE FUNCTION record_text_revision() RETURNS TRIGGER AS $$
+DECLARE
+    last_revision INT;
+    last_revision_at TIMESTAMP;
+BEGIN
+    IF NEW.text IS NOT DISTINCT FROM OLD.text THEN
+        RETURN NEW;
+    END IF;
+
+    SELECT revision, created_at INTO last_revision, last_revision_at
+    FROM text_revisions
+    WHERE text_id = OLD.id
+    ORDER BY revision DESC
+    LIMIT 1;
+
+    IF last_revision_at IS NOT NULL
+        AND last_revision_at > (NOW() AT TIME ZONE 'utc') - INTERVAL '2 minutes'
+        AND NEW.plain_text <> ''
+        AND COALESCE(current_setting('todo.force_text_revision', true), '') <> 'on' THEN
+        RETURN NEW;
+    END IF;
+
+    INSERT INTO text_revisions (text_id, revision, text, plain_text, created_at)
+    VALUES (OLD.id, COALESCE(last_revision, 0) + 1, OLD.text, OLD.plain_text, NOW() AT TIME ZONE 'utc');
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER texts_revision
+BEFORE UPDATE ON texts
+FOR EACH ROW EXECUTE FUNCTION record_text_revision();
@@ -0,0 +1,65 @@
+CREATE TABLE comments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    task_id UUID NOT NULL,
+    author_id VARCHAR(255) NOT NULL,
+    body TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    edited_at TIMESTAMP,
+    deleted_at TIMESTAMP,
+    CONSTRAINT fk_task_id
+        FOREIGN KEY(task_id)
+        REFERENCES tasks(id)
+);
+
+CREATE INDEX idx_comments_task_id ON comments (task_id, created_at, id) WHERE deleted_at IS NULL;
+
+CREATE INDEX idx_activity_events_feed ON activity_events (activity_id, created_at);
+
+-- Record completing and reopening a task as their own actions so the activity
+-- feed can show them.
+CREATE OR REPLACE FUNCTION record_activity_event() RETURNS TRIGGER AS $$
+DECLARE
+    event_action VARCHAR(20) := 'updated';
+    event_activity_id UUID;
+BEGIN
+    IF TG_TABLE_NAME = 'activities' THEN
+        event_activity_id := NEW.id;
+    ELSE
+        event_activity_id := NEW.activity_id;
+    END IF;
+
+    IF TG_OP = 'INSERT' THEN
+        event_action := 'created';
+    ELSIF NEW.deleted_at IS NOT NULL AND OLD.deleted_at IS NULL THEN
+        event_action := 'deleted';
+    ELSIF TG_TABLE_NAME = 'tasks' THEN
+        IF NEW.activity_id IS DISTINCT FROM OLD.activity_id THEN
+            event_action := 'moved';
+
+            -- Let the source activity know the task left
+            INSERT INTO activity_events (activity_id, entity_type, entity_id, action)
+            VALUES (OLD.activity_id, TG_ARGV[0], NEW.id, event_action);
+
+            PERFORM pg_notify('activity_events', OLD.activity_id::text);
+        ELSIF NEW.is_active IS DISTINCT FROM OLD.is_active THEN
+            IF NEW.is_active THEN
+                event_action := 'reopened';
+            ELSE
+                event_action := 'completed';
+            END IF;
+        ELSIF NEW.order_position IS DISTINCT FROM OLD.order_position
+            AND NEW.title IS NOT DISTINCT FROM OLD.title
+            AND NEW.priority IS NOT DISTINCT FROM OLD.priority THEN
+            event_action := 'reordered';
+        END IF;
+    END IF;
+
+    INSERT INTO activity_events (activity_id, entity_type, entity_id, action)
+    VALUES (event_activity_id, TG_ARGV[0], NEW.id, event_action);
+
+    PERFORM pg_notify('activity_events', event_activity_id::text);
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
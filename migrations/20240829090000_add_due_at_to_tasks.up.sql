@@ -0,0 +1 @@
+ALTER TABLE tasks ADD COLUMN due_at TIMESTAMP;
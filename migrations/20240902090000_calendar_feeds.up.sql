@@ -0,0 +1,23 @@
+CREATE TABLE feed_tokens (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id VARCHAR(255) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    activity_id UUID,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    created_at TIMESTAMP NOT NULL,
+    last_used_at TIMESTAMP,
+    revoked_at TIMESTAMP,
+    CONSTRAINT fk_feed_tokens_activity_id
+        FOREIGN KEY(activity_id)
+        REFERENCES activities(id)
+);
+
+CREATE INDEX idx_feed_tokens_user ON feed_tokens (user_id) WHERE revoked_at IS NULL;
+
+-- CalDAV clients choose the resource name and UID of the tasks they create
+-- and expect to find them under the same ones.
+ALTER TABLE tasks ADD COLUMN ical_uid VARCHAR(255);
+ALTER TABLE tasks ADD COLUMN caldav_name VARCHAR(255);
+
+CREATE UNIQUE INDEX idx_tasks_caldav_name ON tasks (activity_id, caldav_name) WHERE caldav_name IS NOT NULL AND deleted_at IS NULL;
+CREATE INDEX idx_tasks_due_at ON tasks (due_at) WHERE due_at IS NOT NULL AND deleted_at IS NULL;
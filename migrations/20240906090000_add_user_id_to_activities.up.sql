@@ -0,0 +1,3 @@
+ALTER TABLE activities ADD COLUMN user_id VARCHAR(255);
+
+CREATE INDEX idx_activities_user ON activities (user_id) WHERE deleted_at IS NULL;
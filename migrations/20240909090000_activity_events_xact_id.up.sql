@@ -0,0 +1,8 @@
+-- Event ids are taken in insert order but become visible in commit order, so
+-- a reader paging by id skips events of transactions that commit late. The
+-- id of the inserting transaction tells readers which events are final:
+-- every transaction below the xmin of the current snapshot has ended.
+ALTER TABLE activity_events ADD COLUMN xact_id XID8 NOT NULL DEFAULT pg_current_xact_id();
+
+CREATE INDEX idx_activity_events_activity_xact ON activity_events (activity_id, xact_id, id);
+CREATE INDEX idx_activity_events_xact ON activity_events (xact_id);
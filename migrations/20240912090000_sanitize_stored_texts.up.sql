@@ -0,0 +1,42 @@
+-- Notes stored before the sanitizer was added are re-rendered once by the
+-- service at startup; rows written since then are already sanitized.
+ALTER TABLE texts ADD COLUMN sanitized BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE texts ALTER COLUMN sanitized SET DEFAULT TRUE;
+
+ALTER TABLE text_revisions ADD COLUMN sanitized BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE text_revisions ALTER COLUMN sanitized SET DEFAULT TRUE;
+
+CREATE INDEX idx_texts_unsanitized ON texts (id) WHERE NOT sanitized;
+CREATE INDEX idx_text_revisions_unsanitized ON text_revisions (text_id, revision) WHERE NOT sanitized;
+
+-- The backfill sets todo.skip_text_revision, so re-rendering a note does not
+-- snapshot its unsanitized content as a new revision.
+CREATE OR REPLACE FUNCTION record_text_revision() RETURNS TRIGGER AS $$
+DECLARE
+    last_revision INT;
+    last_revision_at TIMESTAMP;
+BEGIN
+    IF NEW.text IS NOT DISTINCT FROM OLD.text
+        OR COALESCE(current_setting('todo.skip_text_revision', true), '') = 'on' THEN
+        RETURN NEW;
+    END IF;
+
+    SELECT revision, created_at INTO last_revision, last_revision_at
+    FROM text_revisions
+    WHERE text_id = OLD.id
+    ORDER BY revision DESC
+    LIMIT 1;
+
+    IF last_revision_at IS NOT NULL
+        AND last_revision_at > (NOW() AT TIME ZONE 'utc') - INTERVAL '2 minutes'
+        AND NEW.plain_text <> ''
+        AND COALESCE(current_setting('todo.force_text_revision', true), '') <> 'on' THEN
+        RETURN NEW;
+    END IF;
+
+    INSERT INTO text_revisions (text_id, revision, text, plain_text, created_at)
+    VALUES (OLD.id, COALESCE(last_revision, 0) + 1, OLD.text, OLD.plain_text, NOW() AT TIME ZONE 'utc');
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
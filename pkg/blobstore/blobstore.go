@@ -0,0 +1,44 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	DriverLocal = "local"
+	DriverS3    = "s3"
+)
+
+var ErrNotFound = errors.New("blobstore: object not found")
+
+type (
+	Config struct {
+		Driver string      `mapstructure:"driver"`
+		Local  LocalConfig `mapstructure:"local"`
+		S3     S3Config    `mapstructure:"s3"`
+	}
+
+	// BlobStore keeps opaque objects by key. Put reads r until EOF, size is
+	// -1 when unknown.
+	BlobStore interface {
+		Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+		Get(ctx context.Context, key string) (io.ReadCloser, error)
+		Delete(ctx context.Context, key string) error
+	}
+)
+
+// New returns the store selected by cfg.Driver, the local filesystem by
+// default.
+func New(cfg Config) (BlobStore, error) {
+	switch cfg.Driver {
+	case "", DriverLocal:
+		return NewLocal(cfg.Local)
+	case DriverS3:
+		return NewS3(cfg.S3)
+	default:
+		return nil, fmt.Errorf("blobstore - New: unknown driver %q", cfg.Driver)
+	}
+}
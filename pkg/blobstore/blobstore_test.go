@@ -0,0 +1,132 @@
+package blobstore_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/digisata/todo-service/pkg/blobstore"
+	"github.com/digisata/todo-service/pkg/blobstore/blobstoretest"
+)
+
+func stores(t *testing.T) map[string]blobstore.BlobStore {
+	t.Helper()
+
+	local, err := blobstore.NewLocal(blobstore.LocalConfig{Dir: t.TempDir()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv := blobstoretest.NewS3Server()
+	t.Cleanup(srv.Close)
+
+	s3, err := blobstore.NewS3(srv.Config("attachments"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return map[string]blobstore.BlobStore{
+		blobstore.DriverLocal: local,
+		blobstore.DriverS3:    s3,
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	// Larger than one S3 part so multipart uploads are covered too.
+	large := make([]byte, 6<<20)
+	_, err := rand.Read(large)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string][]byte{
+		"small": []byte("hello, world"),
+		"empty": {},
+		"large": large,
+	}
+
+	for driver, store := range stores(t) {
+		for name, data := range cases {
+			t.Run(driver+"/"+name, func(t *testing.T) {
+				ctx := context.Background()
+				key := "objects/" + name
+
+				err := store.Put(ctx, key, bytes.NewReader(data), -1, "application/octet-stream")
+				if err != nil {
+					t.Fatalf("put: %v", err)
+				}
+
+				r, err := store.Get(ctx, key)
+				if err != nil {
+					t.Fatalf("get: %v", err)
+				}
+				defer r.Close()
+
+				got, err := io.ReadAll(r)
+				if err != nil {
+					t.Fatalf("read: %v", err)
+				}
+
+				if !bytes.Equal(got, data) {
+					t.Errorf("got %d bytes back, want %d", len(got), len(data))
+				}
+			})
+		}
+	}
+}
+
+func TestDelete(t *testing.T) {
+	for driver, store := range stores(t) {
+		t.Run(driver, func(t *testing.T) {
+			ctx := context.Background()
+
+			err := store.Put(ctx, "gone", bytes.NewReader([]byte("x")), 1, "text/plain")
+			if err != nil {
+				t.Fatalf("put: %v", err)
+			}
+
+			err = store.Delete(ctx, "gone")
+			if err != nil {
+				t.Fatalf("delete: %v", err)
+			}
+
+			_, err = store.Get(ctx, "gone")
+			if !errors.Is(err, blobstore.ErrNotFound) {
+				t.Errorf("get after delete: got %v, want %v", err, blobstore.ErrNotFound)
+			}
+
+			err = store.Delete(ctx, "gone")
+			if err != nil {
+				t.Errorf("deleting a missing object: %v", err)
+			}
+		})
+	}
+}
+
+func TestGetMissing(t *testing.T) {
+	for driver, store := range stores(t) {
+		t.Run(driver, func(t *testing.T) {
+			_, err := store.Get(context.Background(), "missing")
+			if !errors.Is(err, blobstore.ErrNotFound) {
+				t.Errorf("got %v, want %v", err, blobstore.ErrNotFound)
+			}
+		})
+	}
+}
+
+func TestLocalRejectsEscapingKeys(t *testing.T) {
+	store, err := blobstore.NewLocal(blobstore.LocalConfig{Dir: t.TempDir()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, key := range []string{"", "../outside", "a/../../outside"} {
+		err := store.Put(context.Background(), key, bytes.NewReader(nil), 0, "")
+		if err == nil {
+			t.Errorf("key %q was accepted", key)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package exporter
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+)
+
+var csvHeader = []string{
+	"activity_id", "activity_title", "activity_type",
+	"record", "id", "content", "completed", "priority", "order", "due_at",
+	"created_at", "updated_at", "archived_at",
+}
+
+// csvWriter writes one row per task or text, prefixed with its activity.
+// Texts are written as plain text.
+type csvWriter struct {
+	w        *csv.Writer
+	started  bool
+	activity Activity
+}
+
+func newCSVWriter(w io.Writer) Writer {
+	return &csvWriter{w: csv.NewWriter(w)}
+}
+
+func (c *csvWriter) BeginActivity(activity Activity) error {
+	c.activity = activity
+
+	return c.start()
+}
+
+func (c *csvWriter) WriteTask(task Task) error {
+	return c.row(
+		"task", task.ID, task.Title,
+		strconv.FormatBool(task.Completed), strconv.Itoa(task.Priority), strconv.Itoa(task.Order), formatTime(task.DueAt),
+		formatTime(&task.CreatedAt), formatTime(&task.UpdatedAt), formatTime(task.ArchivedAt),
+	)
+}
+
+func (c *csvWriter) WriteText(text Text) error {
+	return c.row(
+		"text", text.ID, text.PlainText,
+		"", "", "", "",
+		formatTime(&text.CreatedAt), formatTime(&text.UpdatedAt), "",
+	)
+}
+
+func (c *csvWriter) EndActivity() error {
+	c.w.Flush()
+
+	return c.w.Error()
+}
+
+func (c *csvWriter) Close() error {
+	err := c.start()
+	if err != nil {
+		return err
+	}
+
+	c.w.Flush()
+
+	return c.w.Error()
+}
+
+func (c *csvWriter) start() error {
+	if c.started {
+		return nil
+	}
+	c.started = true
+
+	return c.w.Write(csvHeader)
+}
+
+func (c *csvWriter) row(fields ...string) error {
+	return c.w.Write(append([]string{c.activity.ID, c.activity.Title, c.activity.Type}, fields...))
+}
@@ -0,0 +1,108 @@
+// Package exporter writes activities with their tasks and texts as they are
+// read, so an export never has to be held in memory as a whole.
+package exporter
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+const (
+	FormatJSON     = "json"
+	FormatCSV      = "csv"
+	FormatMarkdown = "markdown"
+	FormatICal     = "ical"
+)
+
+// Version of the JSON document. Bump it on incompatible changes so the
+// importer can refuse files it does not understand.
+const Version = 1
+
+var ErrUnsupportedFormat = errors.New("exporter: unsupported format")
+
+type (
+	Activity struct {
+		ID         string
+		Title      string
+		Type       string
+		CreatedAt  time.Time
+		UpdatedAt  time.Time
+		ArchivedAt *time.Time
+	}
+
+	Task struct {
+		ID string
+		// UID is written by formats with stable identifiers instead of ID
+		// when set, e.g. the UID a calendar app chose for the task.
+		UID        string
+		Title      string
+		Completed  bool
+		Priority   int
+		Order      int
+		DueAt      *time.Time
+		CreatedAt  time.Time
+		UpdatedAt  time.Time
+		ArchivedAt *time.Time
+	}
+
+	Text struct {
+		ID        string
+		Text      string
+		PlainText string
+		CreatedAt time.Time
+		UpdatedAt time.Time
+	}
+
+	// Writer receives an activity, then all its tasks, then all its texts,
+	// then EndActivity, for each activity in turn. Close finishes the file.
+	Writer interface {
+		BeginActivity(activity Activity) error
+		WriteTask(task Task) error
+		WriteText(text Text) error
+		EndActivity() error
+		Close() error
+	}
+
+	format struct {
+		extension   string
+		contentType string
+		newWriter   func(w io.Writer) Writer
+	}
+)
+
+var formats = map[string]format{
+	FormatJSON:     {extension: "json", contentType: "application/json", newWriter: newJSONWriter},
+	FormatCSV:      {extension: "csv", contentType: "text/csv", newWriter: newCSVWriter},
+	FormatMarkdown: {extension: "md", contentType: "text/markdown", newWriter: newMarkdownWriter},
+	FormatICal:     {extension: "ics", contentType: "text/calendar", newWriter: newICalWriter},
+}
+
+// NewWriter returns a writer for the format writing to w.
+func NewWriter(formatName string, w io.Writer) (Writer, error) {
+	f, ok := formats[formatName]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedFormat, formatName)
+	}
+
+	return f.newWriter(w), nil
+}
+
+// File returns the file extension and content type of a format.
+func File(formatName string) (string, string, error) {
+	f, ok := formats[formatName]
+	if !ok {
+		return "", "", fmt.Errorf("%w: %q", ErrUnsupportedFormat, formatName)
+	}
+
+	return f.extension, f.contentType, nil
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+
+	return t.UTC().Format(time.RFC3339)
+}
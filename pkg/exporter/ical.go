@@ -0,0 +1,154 @@
+package exporter
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ICalProductID names this service in the PRODID of its calendars.
+const ICalProductID = "-//digisata//todo-service//EN"
+
+// icalLineLength is the limit of RFC 5545 in octets, without the CRLF.
+const icalLineLength = 75
+
+var icalEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+// icalWriter writes tasks as VTODO and texts as VJOURNAL components of one
+// calendar. The activity title becomes the category of its components.
+type icalWriter struct {
+	w        io.Writer
+	started  bool
+	activity Activity
+	stamp    string
+}
+
+func newICalWriter(w io.Writer) Writer {
+	return &icalWriter{w: w, stamp: icalTime(time.Now())}
+}
+
+func (c *icalWriter) BeginActivity(activity Activity) error {
+	c.activity = activity
+
+	return c.start()
+}
+
+func (c *icalWriter) WriteTask(task Task) error {
+	uid := task.UID
+	if uid == "" {
+		uid = task.ID
+	}
+
+	lines := []string{
+		"BEGIN:VTODO",
+		"UID:" + icalEscaper.Replace(uid),
+		"DTSTAMP:" + c.stamp,
+		"CREATED:" + icalTime(task.CreatedAt),
+		"LAST-MODIFIED:" + icalTime(task.UpdatedAt),
+		"SUMMARY:" + icalEscaper.Replace(task.Title),
+		"CATEGORIES:" + icalEscaper.Replace(c.activity.Title),
+	}
+
+	if task.DueAt != nil {
+		lines = append(lines, "DUE:"+icalTime(*task.DueAt))
+	}
+
+	if priority := ICalPriority(task.Priority); priority != 0 {
+		lines = append(lines, fmt.Sprintf("PRIORITY:%d", priority))
+	}
+
+	if task.Completed {
+		lines = append(lines, "STATUS:COMPLETED")
+	} else {
+		lines = append(lines, "STATUS:NEEDS-ACTION")
+	}
+
+	return c.write(append(lines, "END:VTODO")...)
+}
+
+func (c *icalWriter) WriteText(text Text) error {
+	return c.write(
+		"BEGIN:VJOURNAL",
+		"UID:"+text.ID,
+		"DTSTAMP:"+c.stamp,
+		"CREATED:"+icalTime(text.CreatedAt),
+		"LAST-MODIFIED:"+icalTime(text.UpdatedAt),
+		"SUMMARY:"+icalEscaper.Replace(c.activity.Title),
+		"DESCRIPTION:"+icalEscaper.Replace(text.PlainText),
+		"CATEGORIES:"+icalEscaper.Replace(c.activity.Title),
+		"END:VJOURNAL",
+	)
+}
+
+func (c *icalWriter) EndActivity() error {
+	return nil
+}
+
+func (c *icalWriter) Close() error {
+	err := c.start()
+	if err != nil {
+		return err
+	}
+
+	return c.write("END:VCALENDAR")
+}
+
+func (c *icalWriter) start() error {
+	if c.started {
+		return nil
+	}
+	c.started = true
+
+	return c.write("BEGIN:VCALENDAR", "VERSION:2.0", "PRODID:"+ICalProductID)
+}
+
+func (c *icalWriter) write(lines ...string) error {
+	var b strings.Builder
+	for _, line := range lines {
+		foldICalLine(&b, line)
+	}
+
+	_, err := io.WriteString(c.w, b.String())
+
+	return err
+}
+
+// foldICalLine splits long lines into continuation lines starting with a
+// space, never inside a UTF-8 sequence.
+func foldICalLine(b *strings.Builder, line string) {
+	limit := icalLineLength
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+
+		b.WriteString(line[:cut] + "\r\n ")
+		line = line[cut:]
+		// The leading space counts towards the limit of the next line.
+		limit = icalLineLength - 1
+	}
+
+	b.WriteString(line + "\r\n")
+}
+
+func icalTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// ICalPriority maps high, medium and low to the iCalendar priorities 1, 5
+// and 9; none is 0.
+func ICalPriority(priority int) int {
+	switch {
+	case priority >= 3:
+		return 1
+	case priority == 2:
+		return 5
+	case priority == 1:
+		return 9
+	default:
+		return 0
+	}
+}
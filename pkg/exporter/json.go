@@ -0,0 +1,199 @@
+package exporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"time"
+)
+
+type (
+	jsonDocument struct {
+		Version    int       `json:"version"`
+		ExportedAt time.Time `json:"exported_at"`
+	}
+
+	jsonActivity struct {
+		ID         string     `json:"id"`
+		Title      string     `json:"title"`
+		Type       string     `json:"type"`
+		CreatedAt  time.Time  `json:"created_at"`
+		UpdatedAt  time.Time  `json:"updated_at"`
+		ArchivedAt *time.Time `json:"archived_at"`
+	}
+
+	jsonTask struct {
+		ID         string     `json:"id"`
+		Title      string     `json:"title"`
+		Completed  bool       `json:"completed"`
+		Priority   int        `json:"priority"`
+		Order      int        `json:"order"`
+		DueAt      *time.Time `json:"due_at"`
+		CreatedAt  time.Time  `json:"created_at"`
+		UpdatedAt  time.Time  `json:"updated_at"`
+		ArchivedAt *time.Time `json:"archived_at"`
+	}
+
+	jsonText struct {
+		ID        string    `json:"id"`
+		Text      string    `json:"text"`
+		PlainText string    `json:"plain_text"`
+		CreatedAt time.Time `json:"created_at"`
+		UpdatedAt time.Time `json:"updated_at"`
+	}
+
+	// jsonWriter writes {"version":1,"exported_at":...,"activities":[...]}
+	// piece by piece; every activity carries "tasks" and "texts" arrays.
+	jsonWriter struct {
+		w          io.Writer
+		started    bool
+		activities int
+		items      int
+		inTexts    bool
+	}
+)
+
+func newJSONWriter(w io.Writer) Writer {
+	return &jsonWriter{w: w}
+}
+
+func (j *jsonWriter) BeginActivity(activity Activity) error {
+	err := j.start()
+	if err != nil {
+		return err
+	}
+
+	header, err := json.Marshal(jsonActivity{
+		ID:         activity.ID,
+		Title:      activity.Title,
+		Type:       activity.Type,
+		CreatedAt:  activity.CreatedAt.UTC(),
+		UpdatedAt:  activity.UpdatedAt.UTC(),
+		ArchivedAt: utc(activity.ArchivedAt),
+	})
+	if err != nil {
+		return err
+	}
+
+	if j.activities > 0 {
+		_, err = io.WriteString(j.w, ",")
+		if err != nil {
+			return err
+		}
+	}
+	j.activities++
+	j.items = 0
+	j.inTexts = false
+
+	// Reopen the object to append the arrays.
+	header = append(bytes.TrimSuffix(header, []byte("}")), `,"tasks":[`...)
+	_, err = j.w.Write(header)
+
+	return err
+}
+
+func (j *jsonWriter) WriteTask(task Task) error {
+	return j.item(jsonTask{
+		ID:         task.ID,
+		Title:      task.Title,
+		Completed:  task.Completed,
+		Priority:   task.Priority,
+		Order:      task.Order,
+		DueAt:      utc(task.DueAt),
+		CreatedAt:  task.CreatedAt.UTC(),
+		UpdatedAt:  task.UpdatedAt.UTC(),
+		ArchivedAt: utc(task.ArchivedAt),
+	})
+}
+
+func (j *jsonWriter) WriteText(text Text) error {
+	err := j.openTexts()
+	if err != nil {
+		return err
+	}
+
+	return j.item(jsonText{
+		ID:        text.ID,
+		Text:      text.Text,
+		PlainText: text.PlainText,
+		CreatedAt: text.CreatedAt.UTC(),
+		UpdatedAt: text.UpdatedAt.UTC(),
+	})
+}
+
+func (j *jsonWriter) EndActivity() error {
+	err := j.openTexts()
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(j.w, "]}")
+
+	return err
+}
+
+func (j *jsonWriter) Close() error {
+	err := j.start()
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(j.w, "]}\n")
+
+	return err
+}
+
+func (j *jsonWriter) start() error {
+	if j.started {
+		return nil
+	}
+	j.started = true
+
+	header, err := json.Marshal(jsonDocument{Version: Version, ExportedAt: time.Now().UTC()})
+	if err != nil {
+		return err
+	}
+
+	header = append(bytes.TrimSuffix(header, []byte("}")), `,"activities":[`...)
+	_, err = j.w.Write(header)
+
+	return err
+}
+
+func (j *jsonWriter) openTexts() error {
+	if j.inTexts {
+		return nil
+	}
+	j.inTexts = true
+	j.items = 0
+
+	_, err := io.WriteString(j.w, `],"texts":[`)
+
+	return err
+}
+
+func (j *jsonWriter) item(v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	if j.items > 0 {
+		data = append([]byte(","), data...)
+	}
+	j.items++
+
+	_, err = j.w.Write(data)
+
+	return err
+}
+
+func utc(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+
+	u := t.UTC()
+
+	return &u
+}
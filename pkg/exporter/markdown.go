@@ -0,0 +1,80 @@
+package exporter
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// markdownWriter writes every activity as a heading followed by its tasks as
+// a checklist and its texts as paragraphs.
+type markdownWriter struct {
+	w io.Writer
+	// afterTask is set while the last line written ended a checklist, which
+	// needs a blank line before whatever comes next.
+	afterTask bool
+}
+
+var markdownEscaper = strings.NewReplacer(
+	"\\", "\\\\", "*", "\\*", "_", "\\_", "`", "\\`", "[", "\\[", "]", "\\]",
+	"#", "\\#", "<", "&lt;", "\r\n", " ", "\n", " ",
+)
+
+func newMarkdownWriter(w io.Writer) Writer {
+	return &markdownWriter{w: w}
+}
+
+func (m *markdownWriter) BeginActivity(activity Activity) error {
+	_, err := fmt.Fprintf(m.w, "%s# %s\n\n", m.separator(), markdownEscaper.Replace(activity.Title))
+
+	return err
+}
+
+func (m *markdownWriter) WriteTask(task Task) error {
+	mark := " "
+	if task.Completed {
+		mark = "x"
+	}
+
+	line := fmt.Sprintf("- [%s] %s", mark, markdownEscaper.Replace(task.Title))
+	if task.DueAt != nil {
+		line += " (due " + task.DueAt.UTC().Format("2006-01-02 15:04") + " UTC)"
+	}
+
+	m.afterTask = true
+	_, err := io.WriteString(m.w, line+"\n")
+
+	return err
+}
+
+func (m *markdownWriter) WriteText(text Text) error {
+	// Texts are written from their plain text so markup never leaks in.
+	var b strings.Builder
+	for _, line := range strings.Split(text.PlainText, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" {
+			b.WriteString(markdownEscaper.Replace(line) + "\n\n")
+		}
+	}
+
+	_, err := io.WriteString(m.w, m.separator()+b.String())
+
+	return err
+}
+
+func (m *markdownWriter) EndActivity() error {
+	return nil
+}
+
+func (m *markdownWriter) Close() error {
+	return nil
+}
+
+func (m *markdownWriter) separator() string {
+	if !m.afterTask {
+		return ""
+	}
+	m.afterTask = false
+
+	return "\n"
+}
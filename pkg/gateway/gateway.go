@@ -0,0 +1,101 @@
+// Package gateway serves gRPC services as JSON over HTTP, routed by the
+// google.api.http annotations of their protos. Calls are forwarded to the
+// gRPC server, so they pass the same interceptors as native clients.
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// RegisterFunc is the Register<Service>HandlerFromEndpoint function generated
+// for a service.
+type RegisterFunc func(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption) error
+
+// New returns a handler for the services forwarding to the gRPC server at
+// endpoint. Errors are written as {"code", "message", "details"} with the
+// HTTP status of their gRPC code, e.g. 404 for NOT_FOUND and 400 for
+// INVALID_ARGUMENT.
+func New(ctx context.Context, endpoint string, creds credentials.TransportCredentials, services ...RegisterFunc) (*runtime.ServeMux, error) {
+	mux := runtime.NewServeMux(
+		// Field names match the json_name options, which are the proto
+		// names, and zero values are kept so clients see every field.
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
+			MarshalOptions: protojson.MarshalOptions{
+				UseProtoNames:   true,
+				EmitUnpopulated: true,
+			},
+			UnmarshalOptions: protojson.UnmarshalOptions{
+				DiscardUnknown: true,
+			},
+		}),
+		runtime.WithErrorHandler(errorHandler),
+		runtime.WithRoutingErrorHandler(routingErrorHandler),
+		runtime.WithIncomingHeaderMatcher(headerMatcher),
+	)
+
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(creds)}
+	for _, register := range services {
+		err := register(ctx, mux, endpoint, opts)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return mux, nil
+}
+
+// headerMatcher forwards the W3C trace context and the request ID, so the
+// server spans and logs join those of the HTTP client, and the user the
+// proxy in front of the gateway identified.
+func headerMatcher(key string) (string, bool) {
+	switch strings.ToLower(key) {
+	case "traceparent", "tracestate", "baggage", "x-request-id", "x-user-id":
+		return strings.ToLower(key), true
+	}
+
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+func errorHandler(ctx context.Context, mux *runtime.ServeMux, marshaler runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+	st := status.Convert(err)
+	writeError(w, marshaler, runtime.HTTPStatusFromCode(st.Code()), st)
+}
+
+// routingErrorHandler answers requests no annotation matches in the same
+// shape as errors of the services.
+func routingErrorHandler(ctx context.Context, mux *runtime.ServeMux, marshaler runtime.Marshaler, w http.ResponseWriter, r *http.Request, httpStatus int) {
+	code := codes.Internal
+	switch httpStatus {
+	case http.StatusBadRequest:
+		code = codes.InvalidArgument
+	case http.StatusNotFound:
+		code = codes.NotFound
+	case http.StatusMethodNotAllowed:
+		code = codes.Unimplemented
+	}
+
+	writeError(w, marshaler, httpStatus, status.New(code, http.StatusText(httpStatus)))
+}
+
+func writeError(w http.ResponseWriter, marshaler runtime.Marshaler, httpStatus int, st *status.Status) {
+	pb := st.Proto()
+
+	body, err := marshaler.Marshal(pb)
+	if err != nil {
+		httpStatus = http.StatusInternalServerError
+		body = []byte(`{"code":13,"message":"failed to marshal error message","details":[]}`)
+	}
+
+	w.Header().Set("Content-Type", marshaler.ContentType(pb))
+	w.WriteHeader(httpStatus)
+	w.Write(body)
+}
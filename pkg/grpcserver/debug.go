@@ -0,0 +1,21 @@
+package grpcserver
+
+// DebugConfig switches on the services grpcurl and grpcdebug use. Both are
+// off unless configured, and only callers on the allowlist reach them; see
+// interceptor.Allowlist for who is allowed when the lists are empty.
+type DebugConfig struct {
+	Reflection bool `mapstructure:"reflection"`
+	Channelz   bool `mapstructure:"channelz"`
+	// AllowedClients are common names of client certificates verified
+	// against tls_config.client_ca_file, so they only match over TLS with
+	// client_auth request or require.
+	AllowedClients []string `mapstructure:"allowed_clients"`
+	// AllowedNetworks are CIDRs like 10.0.0.0/8.
+	AllowedNetworks []string `mapstructure:"allowed_networks"`
+}
+
+// debugMethods are the prefixes of the reflection and channelz methods.
+var debugMethods = []string{
+	"/grpc.reflection.",
+	"/grpc.channelz.",
+}
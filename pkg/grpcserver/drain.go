@@ -0,0 +1,58 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	grpcMiddleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// longLivedMethods are the streams that only end when the client leaves.
+// Draining cancels them right away instead of waiting for them until the
+// drain timeout; their clients reconnect to another instance.
+var longLivedMethods = []string{
+	"/proto.ActivityService/Watch",
+	"/proto.TextService/CollaborateText",
+}
+
+var errDraining = errors.New("server is shutting down")
+
+// drainer ends the long-lived streams once the server starts draining, so
+// GracefulStop only waits for the calls that finish on their own.
+type drainer struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func newDrainer() drainer {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return drainer{ctx: ctx, cancel: cancel}
+}
+
+// Stream gives long-lived streams a context that is also cancelled when
+// draining starts. They end with Unavailable then, which clients retry.
+func (d drainer) Stream(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if !slices.Contains(longLivedMethods, info.FullMethod) {
+		return handler(srv, stream)
+	}
+
+	ctx, cancel := context.WithCancelCause(stream.Context())
+	defer cancel(nil)
+
+	stop := context.AfterFunc(d.ctx, func() {
+		cancel(errDraining)
+	})
+	defer stop()
+
+	err := handler(srv, &grpcMiddleware.WrappedServerStream{ServerStream: stream, WrappedContext: ctx})
+	if err != nil && errors.Is(context.Cause(ctx), errDraining) {
+		return status.Error(codes.Unavailable, errDraining.Error())
+	}
+
+	return err
+}
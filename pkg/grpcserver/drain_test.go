@@ -0,0 +1,58 @@
+package grpcserver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s contextStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestDrainEndsLongLivedStreams(t *testing.T) {
+	drain := newDrainer()
+
+	// The handler runs until its stream context is done, like Watch
+	serve := func(method string) <-chan error {
+		done := make(chan error, 1)
+		go func() {
+			done <- drain.Stream(nil, contextStream{ctx: context.Background()}, &grpc.StreamServerInfo{FullMethod: method},
+				func(srv interface{}, stream grpc.ServerStream) error {
+					<-stream.Context().Done()
+					return stream.Context().Err()
+				})
+		}()
+
+		return done
+	}
+
+	watch := serve("/proto.ActivityService/Watch")
+	upload := serve("/proto.AttachmentService/UploadAttachment")
+
+	drain.cancel()
+
+	select {
+	case err := <-watch:
+		if status.Code(err) != codes.Unavailable {
+			t.Errorf("watch: got %v, want %v", err, codes.Unavailable)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("watch still running after draining started")
+	}
+
+	select {
+	case err := <-upload:
+		t.Errorf("upload ended by draining: %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"log"
 	"net"
+	"net/http"
 	"os"
 	"time"
 
@@ -17,6 +18,7 @@ import (
 	grpcCtxtags "github.com/grpc-ecosystem/go-grpc-middleware/tags"
 	grpcPrometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -26,99 +28,245 @@ import (
 
 type (
 	Config struct {
-		Host    string `mapstructure:"host"`
-		Port    string `mapstructure:"PORT"`
-		Network string `mapstructure:"network"`
-		TlS     bool   `mapstructure:"tls"`
+		Host      string          `mapstructure:"host"`
+		Port      string          `mapstructure:"PORT"`
+		Network   string          `mapstructure:"network"`
+		TlS       bool            `mapstructure:"tls"`
+		TLSConfig TLSConfig       `mapstructure:"tls_config"`
+		Web       WebConfig       `mapstructure:"web"`
+		Keepalive KeepaliveConfig `mapstructure:"keepalive"`
+		Debug     DebugConfig     `mapstructure:"debug"`
+		// Message sizes are in bytes; 0 keeps the gRPC defaults of 4 MiB
+		// received and no limit sent.
+		MaxRecvMsgSize int `mapstructure:"max_recv_msg_size"`
+		MaxSendMsgSize int `mapstructure:"max_send_msg_size"`
+		// MaxConcurrentStreams is per connection. The HTTP gateway shares
+		// one connection, so keep it well above the gateway's load; 0 means
+		// no limit.
+		MaxConcurrentStreams uint32 `mapstructure:"max_concurrent_streams"`
+		// DefaultTimeout is the deadline of unary calls sent without one;
+		// 0 leaves them without.
+		DefaultTimeout time.Duration `mapstructure:"default_timeout"`
+	}
+
+	// KeepaliveConfig bounds how long connections live. 0 keeps the defaults
+	// below, except for MaxConnectionAgeGrace, where 0 lets the calls on an
+	// aged connection run to completion.
+	KeepaliveConfig struct {
+		// MaxConnectionIdle closes connections without calls for this long
+		// (5m).
+		MaxConnectionIdle time.Duration `mapstructure:"max_connection_idle"`
+		// MaxConnectionAge asks clients to reconnect after this long (5m), so
+		// load spreads over new instances.
+		MaxConnectionAge time.Duration `mapstructure:"max_connection_age"`
+		// MaxConnectionAgeGrace is how long calls on an aged connection may
+		// still run before it is closed.
+		MaxConnectionAgeGrace time.Duration `mapstructure:"max_connection_age_grace"`
+		// Time is how long a quiet connection waits before pinging the
+		// client (10m), and Timeout how long it waits for the reply (15s).
+		Time    time.Duration `mapstructure:"time"`
+		Timeout time.Duration `mapstructure:"timeout"`
 	}
 
 	GrpcServer struct {
 		*grpc.Server
-		logger   *zap.SugaredLogger
-		Listener net.Listener
-		Host     string
-		Port     string
-		Network  string
+		logger    *zap.SugaredLogger
+		Listener  net.Listener
+		Host      string
+		Port      string
+		Network   string
+		web       WebConfig
+		webServer *http.Server
+		certs     *certReloader
+		webCerts  *certReloader
+		drain     drainer
 	}
 )
 
 const (
-	maxConnectionIdle time.Duration = 300
-	gRPCTimeout       time.Duration = 15
-	maxConnectionAge  time.Duration = 300
-	gRPCTime          time.Duration = 600
-
-	serverCertFile   = "cert/server-cert.pem"
-	serverKeyFile    = "cert/server-key.pem"
-	clientCACertFile = "cert/ca-cert.pem"
+	defaultMaxConnectionIdle = 5 * time.Minute
+	defaultMaxConnectionAge  = 5 * time.Minute
+	defaultKeepaliveTime     = 10 * time.Minute
+	defaultKeepaliveTimeout  = 15 * time.Second
 )
 
-func loadTLSCredentials() (credentials.TransportCredentials, error) {
-	// Load certificate of the CA who signed client's certificate
-	pemClientCA, err := os.ReadFile(clientCACertFile)
+// ClientCredentials returns the credentials in-process clients, like the
+// HTTP gateway, use to reach the server. The client certificate is read for
+// every handshake, so it can be rotated like the server one.
+func ClientCredentials(cfg Config) (credentials.TransportCredentials, error) {
+	if !cfg.TlS {
+		return insecure.NewCredentials(), nil
+	}
+
+	tlsCfg := cfg.TLSConfig.withDefaults()
+
+	// ssl-gen.sh signs the server and client certificates with one CA
+	pemServerCA, err := os.ReadFile(tlsCfg.ClientCAFile)
 	if err != nil {
 		return nil, err
 	}
 
 	certPool := x509.NewCertPool()
-	if !certPool.AppendCertsFromPEM(pemClientCA) {
-		return nil, fmt.Errorf("failed to add client CA's certificate")
+	if !certPool.AppendCertsFromPEM(pemServerCA) {
+		return nil, fmt.Errorf("failed to add server CA's certificate")
 	}
 
-	// Load server's certificate and private key
-	serverCert, err := tls.LoadX509KeyPair(serverCertFile, serverKeyFile)
-	if err != nil {
-		return nil, err
+	config := &tls.Config{
+		RootCAs: certPool,
 	}
 
-	// Create the credentials and return it
-	config := &tls.Config{
-		Certificates: []tls.Certificate{serverCert},
-		ClientAuth:   tls.RequireAndVerifyClientCert,
-		ClientCAs:    certPool,
+	if tlsCfg.ClientAuth != ClientAuthNone {
+		// Fail at startup rather than on the first call
+		_, err = tls.LoadX509KeyPair(tlsCfg.ClientCertFile, tlsCfg.ClientKeyFile)
+		if err != nil {
+			return nil, err
+		}
+
+		config.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			clientCert, err := tls.LoadX509KeyPair(tlsCfg.ClientCertFile, tlsCfg.ClientKeyFile)
+			if err != nil {
+				return nil, err
+			}
+
+			return &clientCert, nil
+		}
 	}
 
 	return credentials.NewTLS(config), nil
 }
 
+func (cfg KeepaliveConfig) serverParameters() keepalive.ServerParameters {
+	params := keepalive.ServerParameters{
+		MaxConnectionIdle:     cfg.MaxConnectionIdle,
+		MaxConnectionAge:      cfg.MaxConnectionAge,
+		MaxConnectionAgeGrace: cfg.MaxConnectionAgeGrace,
+		Time:                  cfg.Time,
+		Timeout:               cfg.Timeout,
+	}
+
+	if params.MaxConnectionIdle <= 0 {
+		params.MaxConnectionIdle = defaultMaxConnectionIdle
+	}
+
+	if params.MaxConnectionAge <= 0 {
+		params.MaxConnectionAge = defaultMaxConnectionAge
+	}
+
+	if params.Time <= 0 {
+		params.Time = defaultKeepaliveTime
+	}
+
+	if params.Timeout <= 0 {
+		params.Timeout = defaultKeepaliveTimeout
+	}
+
+	return params
+}
+
+// Endpoint is the address in-process clients dial.
+func (cfg Config) Endpoint() string {
+	return net.JoinHostPort(cfg.Host, cfg.Port)
+}
+
+// RequiresClientCert reports whether every caller has to present a client
+// certificate.
+func (cfg Config) RequiresClientCert() bool {
+	return cfg.TlS && cfg.TLSConfig.withDefaults().ClientAuth == ClientAuthRequire
+}
+
 func NewGrpcServer(cfg Config, logger *zap.SugaredLogger, im interceptor.InterceptorManager, opts ...grpc.ServerOption) (*GrpcServer, error) {
 	log.Println("Starting gRPC server...")
+	debugAllowlist, err := interceptor.NewAllowlist(debugMethods, cfg.Debug.AllowedClients, cfg.Debug.AllowedNetworks)
+	if err != nil {
+		return nil, err
+	}
+
+	var certs *certReloader
 	if cfg.TlS {
-		creds, err := loadTLSCredentials()
+		certs, err = newCertReloader(cfg.TLSConfig.withDefaults(), logger)
 		if err != nil {
 			return nil, err
 		}
 
+		creds, err := certs.serverCredentials()
+		if err != nil {
+			certs.Close()
+			return nil, err
+		}
+
 		opts = append(opts, grpc.Creds(creds))
 	} else {
 		opts = append(opts, grpc.Creds(insecure.NewCredentials()))
 	}
 
+	var webCerts *certReloader
+	if cfg.Web.Enabled && cfg.Web.TlS {
+		webCerts, err = newCertReloader(cfg.Web.TLSConfig.withDefaults(), logger)
+		if err != nil {
+			if certs != nil {
+				certs.Close()
+			}
+			return nil, err
+		}
+	}
+
+	drain := newDrainer()
+
 	opts = append(
 		opts,
-		grpc.KeepaliveParams(keepalive.ServerParameters{
-			MaxConnectionIdle: maxConnectionIdle * time.Second,
-			Timeout:           gRPCTimeout * time.Second,
-			MaxConnectionAge:  maxConnectionAge * time.Second,
-			Time:              gRPCTime * time.Second,
-		}),
+		grpc.KeepaliveParams(cfg.Keepalive.serverParameters()),
+		grpc.MaxConcurrentStreams(cfg.MaxConcurrentStreams),
+		// Spans start from the W3C trace context in the incoming metadata.
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		// Both chains have the same steps in the same order, except for the
+		// default deadline, which streams do not get, and the drain, which
+		// only ends streams; add auth or validation to both.
 		grpc.UnaryInterceptor(grpcMiddleware.ChainUnaryServer(
 			grpcCtxtags.UnaryServerInterceptor(),
 			grpcPrometheus.UnaryServerInterceptor,
 			grpcRecovery.UnaryServerInterceptor(),
+			interceptor.DefaultDeadline(cfg.DefaultTimeout),
 			im.Logger,
+			debugAllowlist.Unary,
+			im.RateLimit,
+		)),
+		grpc.StreamInterceptor(grpcMiddleware.ChainStreamServer(
+			grpcCtxtags.StreamServerInterceptor(),
+			grpcPrometheus.StreamServerInterceptor,
+			grpcRecovery.StreamServerInterceptor(),
+			im.StreamLogger,
+			debugAllowlist.Stream,
+			im.StreamRateLimit,
+			drain.Stream,
 		)),
 	)
 
+	if cfg.MaxRecvMsgSize > 0 {
+		opts = append(opts, grpc.MaxRecvMsgSize(cfg.MaxRecvMsgSize))
+	}
+
+	if cfg.MaxSendMsgSize > 0 {
+		opts = append(opts, grpc.MaxSendMsgSize(cfg.MaxSendMsgSize))
+	}
+
+	// Stop waits for the handlers, so their cleanup finishes before the
+	// database goes away.
+	opts = append(opts, grpc.WaitForHandlers(true))
+
 	server := grpc.NewServer(opts...)
+	grpcPrometheus.EnableHandlingTimeHistogram()
 	grpcPrometheus.Register(server)
 
 	return &GrpcServer{
-		logger:  logger,
-		Server:  server,
-		Network: cfg.Network,
-		Host:    cfg.Host,
-		Port:    cfg.Port,
+		logger:   logger,
+		Server:   server,
+		Network:  cfg.Network,
+		Host:     cfg.Host,
+		Port:     cfg.Port,
+		web:      cfg.Web,
+		certs:    certs,
+		webCerts: webCerts,
+		drain:    drain,
 	}, nil
 }
 
@@ -130,6 +278,13 @@ func (grpcServer *GrpcServer) Run() error {
 
 	grpcServer.Listener = listener
 
+	if grpcServer.web.Enabled {
+		err = grpcServer.runWeb()
+		if err != nil {
+			return err
+		}
+	}
+
 	log.Println("gRPC server listening on", grpcServer.Port)
 	if err := grpcServer.Server.Serve(grpcServer.Listener); err != nil {
 		return err
@@ -138,15 +293,46 @@ func (grpcServer *GrpcServer) Run() error {
 	return nil
 }
 
-func (grpcServer GrpcServer) Stop(ctx context.Context) {
-	if err := grpcServer.Listener.Close(); err != nil {
-		grpcServer.logger.Fatalw(constans.FATAL,
-			"error", err.Error(),
-		)
-	}
+// Stop refuses new RPCs and waits for the running ones until ctx is done,
+// then cancels whatever is left. Long-lived streams are cancelled right away.
+// It returns once every handler has returned.
+func (grpcServer *GrpcServer) Stop(ctx context.Context) {
+	grpcServer.drain.cancel()
+
+	webStopped := make(chan struct{})
+	go func() {
+		defer close(webStopped)
+
+		if err := grpcServer.stopWeb(ctx); err != nil {
+			grpcServer.logger.Errorw(constans.ERROR,
+				"error", err.Error(),
+			)
+		}
+	}()
 
+	stopped := make(chan struct{})
 	go func() {
-		defer grpcServer.Server.GracefulStop()
-		<-ctx.Done()
+		defer close(stopped)
+		grpcServer.Server.GracefulStop()
 	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		grpcServer.logger.Warnw(constans.WARN,
+			"message", "drain timeout exceeded, cancelling in-flight RPCs",
+		)
+		grpcServer.Server.Stop()
+		<-stopped
+	}
+
+	<-webStopped
+
+	if grpcServer.certs != nil {
+		grpcServer.certs.Close()
+	}
+
+	if grpcServer.webCerts != nil {
+		grpcServer.webCerts.Close()
+	}
 }
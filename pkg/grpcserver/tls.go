@@ -0,0 +1,287 @@
+package grpcserver
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/digisata/todo-service/pkg/constans"
+	"github.com/fsnotify/fsnotify"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/credentials"
+)
+
+const (
+	ClientAuthNone    = "none"
+	ClientAuthRequest = "request"
+	ClientAuthRequire = "require"
+
+	defaultCertFile       = "cert/server-cert.pem"
+	defaultKeyFile        = "cert/server-key.pem"
+	defaultClientCAFile   = "cert/ca-cert.pem"
+	defaultClientCertFile = "cert/client-cert.pem"
+	defaultClientKeyFile  = "cert/client-key.pem"
+
+	// Rotations write several files; reload once they are all in place.
+	reloadDelay = 200 * time.Millisecond
+)
+
+// TLSConfig is used when tls is on. Empty paths default to the files
+// script/ssl-gen.sh writes to cert/.
+type TLSConfig struct {
+	CertFile string `mapstructure:"cert_file"`
+	KeyFile  string `mapstructure:"key_file"`
+	// ClientCAFile verifies client certificates and is the CA in-process
+	// clients trust for the server certificate.
+	ClientCAFile string `mapstructure:"client_ca_file"`
+	// ClientAuth is none, request (verified when sent) or require, the
+	// default.
+	ClientAuth string `mapstructure:"client_auth"`
+	// MinVersion is 1.2, the default, or 1.3.
+	MinVersion string `mapstructure:"min_version"`
+	// CipherSuites are Go names like TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256.
+	// They only apply to TLS 1.2; Go picks the TLS 1.3 suites itself.
+	CipherSuites []string `mapstructure:"cipher_suites"`
+	// ClientCertFile and ClientKeyFile are what in-process clients, like
+	// the HTTP gateway, present when client certificates are asked for.
+	ClientCertFile string `mapstructure:"client_cert_file"`
+	ClientKeyFile  string `mapstructure:"client_key_file"`
+}
+
+func (cfg TLSConfig) withDefaults() TLSConfig {
+	cfg.CertFile = valueOr(cfg.CertFile, defaultCertFile)
+	cfg.KeyFile = valueOr(cfg.KeyFile, defaultKeyFile)
+	cfg.ClientCAFile = valueOr(cfg.ClientCAFile, defaultClientCAFile)
+	cfg.ClientAuth = valueOr(cfg.ClientAuth, ClientAuthRequire)
+	cfg.MinVersion = valueOr(cfg.MinVersion, "1.2")
+	cfg.ClientCertFile = valueOr(cfg.ClientCertFile, defaultClientCertFile)
+	cfg.ClientKeyFile = valueOr(cfg.ClientKeyFile, defaultClientKeyFile)
+
+	return cfg
+}
+
+func valueOr(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+
+	return value
+}
+
+func (cfg TLSConfig) clientAuthType() (tls.ClientAuthType, error) {
+	switch cfg.ClientAuth {
+	case ClientAuthNone:
+		return tls.NoClientCert, nil
+	case ClientAuthRequest:
+		return tls.VerifyClientCertIfGiven, nil
+	case ClientAuthRequire:
+		return tls.RequireAndVerifyClientCert, nil
+	default:
+		return 0, fmt.Errorf("unknown client auth %q", cfg.ClientAuth)
+	}
+}
+
+func (cfg TLSConfig) minVersion() (uint16, error) {
+	switch cfg.MinVersion {
+	case "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	default:
+		return 0, fmt.Errorf("unsupported min version %q", cfg.MinVersion)
+	}
+}
+
+// cipherSuites refuses the suites Go considers insecure.
+func (cfg TLSConfig) cipherSuites() ([]uint16, error) {
+	var ids []uint16
+	for _, name := range cfg.CipherSuites {
+		i := slices.IndexFunc(tls.CipherSuites(), func(suite *tls.CipherSuite) bool {
+			return suite.Name == name
+		})
+		if i < 0 {
+			return nil, fmt.Errorf("unknown or insecure cipher suite %q", name)
+		}
+
+		ids = append(ids, tls.CipherSuites()[i].ID)
+	}
+
+	return ids, nil
+}
+
+// certReloader serves the certificate and client CAs last read from disk and
+// reads them again when their files change, so rotated certificates are used
+// without a restart. Directories are watched rather than files, because
+// Kubernetes swaps the files of a secret by replacing a symlink.
+type certReloader struct {
+	cfg     TLSConfig
+	logger  *zap.SugaredLogger
+	watcher *fsnotify.Watcher
+
+	mu        sync.RWMutex
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+}
+
+func newCertReloader(cfg TLSConfig, logger *zap.SugaredLogger) (*certReloader, error) {
+	r := &certReloader{
+		cfg:    cfg,
+		logger: logger,
+	}
+
+	err := r.load()
+	if err != nil {
+		return nil, err
+	}
+
+	r.watcher, err = fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	var dirs []string
+	for _, file := range []string{cfg.CertFile, cfg.KeyFile, cfg.ClientCAFile} {
+		dir := filepath.Dir(file)
+		if slices.Contains(dirs, dir) {
+			continue
+		}
+
+		err = r.watcher.Add(dir)
+		if err != nil {
+			r.watcher.Close()
+			return nil, err
+		}
+
+		dirs = append(dirs, dir)
+	}
+
+	go r.watch()
+
+	return r, nil
+}
+
+func (r *certReloader) load() error {
+	cert, err := tls.LoadX509KeyPair(r.cfg.CertFile, r.cfg.KeyFile)
+	if err != nil {
+		return err
+	}
+
+	pemClientCA, err := os.ReadFile(r.cfg.ClientCAFile)
+	if err != nil {
+		return err
+	}
+
+	certPool := x509.NewCertPool()
+	if !certPool.AppendCertsFromPEM(pemClientCA) {
+		return fmt.Errorf("failed to add client CA's certificate")
+	}
+
+	r.mu.Lock()
+	r.cert = &cert
+	r.clientCAs = certPool
+	r.mu.Unlock()
+
+	return nil
+}
+
+// watch reloads a moment after the last change. A failed reload keeps the
+// previous certificate, as the files can be caught halfway through a
+// rotation; the next change tries again.
+func (r *certReloader) watch() {
+	timer := time.NewTimer(reloadDelay)
+	timer.Stop()
+
+	for {
+		select {
+		case _, ok := <-r.watcher.Events:
+			if !ok {
+				timer.Stop()
+				return
+			}
+
+			timer.Reset(reloadDelay)
+		case err, ok := <-r.watcher.Errors:
+			if !ok {
+				timer.Stop()
+				return
+			}
+
+			r.logger.Errorw(constans.ERROR,
+				"error", err.Error(),
+			)
+		case <-timer.C:
+			err := r.load()
+			if err != nil {
+				r.logger.Errorw(constans.ERROR,
+					"error", err.Error(),
+				)
+				continue
+			}
+
+			r.logger.Infow(constans.INFO,
+				"message", "reloaded TLS certificates",
+			)
+		}
+	}
+}
+
+func (r *certReloader) Close() error {
+	return r.watcher.Close()
+}
+
+// serverCredentials reads the certificate and client CAs for every
+// handshake, so connections opened after a reload use the new files.
+func (r *certReloader) serverCredentials() (credentials.TransportCredentials, error) {
+	config, err := r.tlsConfig("h2")
+	if err != nil {
+		return nil, err
+	}
+
+	return credentials.NewTLS(config), nil
+}
+
+// tlsConfig builds the server config from the settings and the files last
+// loaded. The config of each handshake comes from GetConfigForClient, so
+// everything the handshake needs, the ALPN protocols included, has to be on
+// the base config it clones.
+func (r *certReloader) tlsConfig(nextProtos ...string) (*tls.Config, error) {
+	clientAuth, err := r.cfg.clientAuthType()
+	if err != nil {
+		return nil, err
+	}
+
+	minVersion, err := r.cfg.minVersion()
+	if err != nil {
+		return nil, err
+	}
+
+	cipherSuites, err := r.cfg.cipherSuites()
+	if err != nil {
+		return nil, err
+	}
+
+	config := &tls.Config{
+		ClientAuth:   clientAuth,
+		MinVersion:   minVersion,
+		CipherSuites: cipherSuites,
+		NextProtos:   nextProtos,
+	}
+	config.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+
+		c := config.Clone()
+		c.GetConfigForClient = nil
+		c.Certificates = []tls.Certificate{*r.cert}
+		c.ClientCAs = r.clientCAs
+
+		return c, nil
+	}
+
+	return config, nil
+}
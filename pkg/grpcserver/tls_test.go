@@ -0,0 +1,281 @@
+package grpcserver
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// testCA signs certificates like script/ssl-gen.sh: one CA for the server
+// and the client certificates.
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newTestCA(t *testing.T) testCA {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return testCA{cert: cert, key: key}
+}
+
+func (ca testCA) writeCA(t *testing.T, certFile string) {
+	t.Helper()
+
+	writePEM(t, certFile, "CERTIFICATE", ca.cert.Raw)
+}
+
+// issue writes a certificate signed by the CA and its key.
+func (ca testCA) issue(t *testing.T, serial int64, usage x509.ExtKeyUsage, certFile, keyFile string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The key goes first, so a reload never pairs the new certificate with
+	// the old key.
+	writePEM(t, keyFile, "PRIVATE KEY", keyDER)
+	writePEM(t, certFile, "CERTIFICATE", der)
+}
+
+func writePEM(t *testing.T, file, blockType string, der []byte) {
+	t.Helper()
+
+	err := os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// newTestTLS writes a CA, a server and a client certificate to a temporary
+// directory and returns the settings pointing at them.
+func newTestTLS(t *testing.T) (TLSConfig, testCA) {
+	t.Helper()
+
+	dir := t.TempDir()
+	cfg := TLSConfig{
+		CertFile:       filepath.Join(dir, "server-cert.pem"),
+		KeyFile:        filepath.Join(dir, "server-key.pem"),
+		ClientCAFile:   filepath.Join(dir, "ca-cert.pem"),
+		ClientCertFile: filepath.Join(dir, "client-cert.pem"),
+		ClientKeyFile:  filepath.Join(dir, "client-key.pem"),
+	}
+
+	ca := newTestCA(t)
+	ca.writeCA(t, cfg.ClientCAFile)
+	ca.issue(t, 2, x509.ExtKeyUsageServerAuth, cfg.CertFile, cfg.KeyFile)
+	ca.issue(t, 3, x509.ExtKeyUsageClientAuth, cfg.ClientCertFile, cfg.ClientKeyFile)
+
+	return cfg.withDefaults(), ca
+}
+
+// serveHealth runs a gRPC server with the health service on the reloader's
+// credentials and returns its address.
+func serveHealth(t *testing.T, certs *certReloader) string {
+	t.Helper()
+
+	creds, err := certs.serverCredentials()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := grpc.NewServer(grpc.Creds(creds))
+	grpc_health_v1.RegisterHealthServer(server, health.NewServer())
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	return lis.Addr().String()
+}
+
+func newTestReloader(t *testing.T, cfg TLSConfig) *certReloader {
+	t.Helper()
+
+	certs, err := newCertReloader(cfg, zap.NewNop().Sugar())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { certs.Close() })
+
+	return certs
+}
+
+func checkHealth(addr string, cfg Config) error {
+	creds, err := ClientCredentials(cfg)
+	if err != nil {
+		return err
+	}
+
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err = grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+
+	return err
+}
+
+// handshake connects like a gRPC client and returns the connection state.
+func handshake(t *testing.T, addr string, cfg TLSConfig) tls.ConnectionState {
+	t.Helper()
+
+	pemCA, err := os.ReadFile(cfg.ClientCAFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	roots := x509.NewCertPool()
+	roots.AppendCertsFromPEM(pemCA)
+
+	clientCert, err := tls.LoadX509KeyPair(cfg.ClientCertFile, cfg.ClientKeyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	conn, err := tls.Dial("tcp", addr, &tls.Config{
+		RootCAs:      roots,
+		ServerName:   "localhost",
+		Certificates: []tls.Certificate{clientCert},
+		NextProtos:   []string{"h2"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	return conn.ConnectionState()
+}
+
+func TestServerCredentialsNegotiateH2(t *testing.T) {
+	cfg, _ := newTestTLS(t)
+	addr := serveHealth(t, newTestReloader(t, cfg))
+
+	// Clients that enforce ALPN refuse servers that do not select h2.
+	got := handshake(t, addr, cfg).NegotiatedProtocol
+	if got != "h2" {
+		t.Errorf("negotiated %q, want h2", got)
+	}
+}
+
+func TestServerCredentialsClientAuth(t *testing.T) {
+	cfg, _ := newTestTLS(t)
+	addr := serveHealth(t, newTestReloader(t, cfg))
+
+	err := checkHealth(addr, Config{TlS: true, TLSConfig: cfg})
+	if err != nil {
+		t.Fatalf("client with a certificate: %v", err)
+	}
+
+	// A client that presents no certificate is turned away.
+	withoutCert := cfg
+	withoutCert.ClientAuth = ClientAuthNone
+
+	err = checkHealth(addr, Config{TlS: true, TLSConfig: withoutCert})
+	if err == nil {
+		t.Fatal("client without a certificate was accepted")
+	}
+}
+
+func TestCertReloaderPicksUpRotatedCertificate(t *testing.T) {
+	cfg, ca := newTestTLS(t)
+	addr := serveHealth(t, newTestReloader(t, cfg))
+
+	serial := handshake(t, addr, cfg).PeerCertificates[0].SerialNumber
+	if serial.Int64() != 2 {
+		t.Fatalf("serving serial %v, want 2", serial)
+	}
+
+	ca.issue(t, 4, x509.ExtKeyUsageServerAuth, cfg.CertFile, cfg.KeyFile)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		serial = handshake(t, addr, cfg).PeerCertificates[0].SerialNumber
+		if serial.Int64() == 4 {
+			break
+		}
+
+		if time.Now().After(deadline) {
+			t.Fatalf("still serving serial %v after the rotation", serial)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	// New connections work with the rotated certificate.
+	err := checkHealth(addr, Config{TlS: true, TLSConfig: cfg})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
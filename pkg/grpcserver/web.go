@@ -0,0 +1,216 @@
+package grpcserver
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"connectrpc.com/vanguard"
+	"connectrpc.com/vanguard/vanguardgrpc"
+	"github.com/pkg/errors"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+type (
+	// WebConfig enables the gRPC-Web and Connect listener. Browsers cannot
+	// speak gRPC, so the same services are served on a second port over
+	// HTTP/1.1 and HTTP/2, in cleartext unless tls is on.
+	WebConfig struct {
+		Enabled bool   `mapstructure:"enabled"`
+		Port    string `mapstructure:"port"`
+		TlS     bool   `mapstructure:"tls"`
+		// TLSConfig has the same defaults as the gRPC server's, client
+		// certificates required included; browsers usually need none or
+		// request.
+		TLSConfig TLSConfig  `mapstructure:"tls_config"`
+		Cors      CorsConfig `mapstructure:"cors"`
+	}
+
+	// CorsConfig lists the origins allowed to call the web listener. No
+	// origins means cross-origin requests are refused. The "*" origin cannot
+	// be combined with AllowCredentials, as it would let every site call the
+	// services with the user's cookies.
+	CorsConfig struct {
+		AllowedOrigins   []string `mapstructure:"allowed_origins"`
+		AllowedHeaders   []string `mapstructure:"allowed_headers"`
+		AllowCredentials bool     `mapstructure:"allow_credentials"`
+		MaxAge           int      `mapstructure:"max_age"`
+	}
+)
+
+var (
+	corsAllowedMethods = []string{http.MethodGet, http.MethodPost}
+
+	// Request headers set by the gRPC-Web and Connect clients.
+	corsAllowedHeaders = []string{
+		"Content-Type",
+		"Connect-Protocol-Version",
+		"Connect-Timeout-Ms",
+		"Connect-Accept-Encoding",
+		"Connect-Content-Encoding",
+		"Grpc-Timeout",
+		"Grpc-Accept-Encoding",
+		"Grpc-Encoding",
+		"X-Grpc-Web",
+		"X-User-Agent",
+	}
+
+	// Response headers the clients read the status from.
+	corsExposedHeaders = []string{
+		"Grpc-Status",
+		"Grpc-Message",
+		"Grpc-Status-Details-Bin",
+		"Grpc-Encoding",
+		"Connect-Content-Encoding",
+	}
+)
+
+func init() {
+	// Connect clients default to JSON, which the gRPC server only decodes
+	// once a codec is registered for it.
+	encoding.RegisterCodec(vanguardgrpc.NewCodec(&vanguard.JSONCodec{
+		MarshalOptions:   protojson.MarshalOptions{EmitUnpopulated: true},
+		UnmarshalOptions: protojson.UnmarshalOptions{DiscardUnknown: true},
+	}))
+}
+
+// Validate refuses CORS settings that would open the services to every site.
+func (cfg WebConfig) Validate() error {
+	if cfg.Cors.AllowCredentials && slices.Contains(cfg.Cors.AllowedOrigins, "*") {
+		return fmt.Errorf("cors: allow_credentials cannot be combined with the \"*\" origin")
+	}
+
+	return nil
+}
+
+// runWeb serves the registered services as gRPC-Web and Connect. It reads
+// the service list from the server, so it must run after registration.
+func (grpcServer *GrpcServer) runWeb() error {
+	transcoder, err := vanguardgrpc.NewTranscoder(grpcServer.Server)
+	if err != nil {
+		return errors.Wrap(err, "vanguardgrpc.NewTranscoder")
+	}
+
+	listener, err := net.Listen(grpcServer.Network, fmt.Sprintf(":%v", grpcServer.web.Port))
+	if err != nil {
+		return errors.Wrap(err, "net.Listen")
+	}
+
+	handler := withCors(grpcServer.web.Cors, transcoder)
+
+	if grpcServer.webCerts == nil {
+		grpcServer.webServer = &http.Server{
+			Handler: h2c.NewHandler(handler, &http2.Server{}),
+		}
+	} else {
+		tlsConfig, err := grpcServer.webCerts.tlsConfig("h2", "http/1.1")
+		if err != nil {
+			listener.Close()
+			return err
+		}
+
+		grpcServer.webServer = &http.Server{
+			Handler:   handler,
+			TLSConfig: tlsConfig,
+		}
+
+		err = http2.ConfigureServer(grpcServer.webServer, &http2.Server{})
+		if err != nil {
+			listener.Close()
+			return errors.Wrap(err, "http2.ConfigureServer")
+		}
+
+		listener = tls.NewListener(listener, tlsConfig)
+	}
+
+	go func() {
+		log.Println("gRPC-Web server listening on", grpcServer.web.Port)
+		err := grpcServer.webServer.Serve(listener)
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			grpcServer.logger.Errorw("gRPC-Web server stopped",
+				"error", err.Error(),
+			)
+		}
+	}()
+
+	return nil
+}
+
+func (grpcServer *GrpcServer) stopWeb(ctx context.Context) error {
+	if grpcServer.webServer == nil {
+		return nil
+	}
+
+	err := grpcServer.webServer.Shutdown(ctx)
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return grpcServer.webServer.Close()
+	}
+
+	return err
+}
+
+func withCors(cfg CorsConfig, next http.Handler) http.Handler {
+	allowedHeaders := strings.Join(append(slices.Clone(corsAllowedHeaders), cfg.AllowedHeaders...), ", ")
+	allowedMethods := strings.Join(corsAllowedMethods, ", ")
+	exposedHeaders := strings.Join(corsExposedHeaders, ", ")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		isPreflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+
+		header := w.Header()
+		header.Add("Vary", "Origin")
+		if isPreflight {
+			header.Add("Vary", "Access-Control-Request-Method")
+			header.Add("Vary", "Access-Control-Request-Headers")
+		}
+
+		if origin == "" || !isAllowedOrigin(cfg.AllowedOrigins, origin) {
+			if isPreflight {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		header.Set("Access-Control-Allow-Origin", origin)
+		if cfg.AllowCredentials {
+			header.Set("Access-Control-Allow-Credentials", "true")
+		}
+
+		if !isPreflight {
+			header.Set("Access-Control-Expose-Headers", exposedHeaders)
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		header.Set("Access-Control-Allow-Methods", allowedMethods)
+		header.Set("Access-Control-Allow-Headers", allowedHeaders)
+		if cfg.MaxAge > 0 {
+			header.Set("Access-Control-Max-Age", strconv.Itoa(cfg.MaxAge))
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+func isAllowedOrigin(allowedOrigins []string, origin string) bool {
+	for _, allowed := range allowedOrigins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+	}
+
+	return false
+}
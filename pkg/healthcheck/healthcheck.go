@@ -0,0 +1,278 @@
+package healthcheck
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/digisata/todo-service/pkg/constans"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const (
+	// LivenessService and ReadinessService are the service names gRPC
+	// probes ask for. The empty name reports readiness as well.
+	LivenessService  = "liveness"
+	ReadinessService = "readiness"
+
+	defaultInterval = 10 * time.Second
+	defaultTimeout  = 2 * time.Second
+)
+
+type (
+	Config struct {
+		// Interval between two rounds of checks; 10 seconds when unset.
+		Interval time.Duration `mapstructure:"interval"`
+		// Timeout of a single check; 2 seconds when unset.
+		Timeout time.Duration `mapstructure:"timeout"`
+	}
+
+	// Check reports whether a dependency can be used.
+	Check func(ctx context.Context) error
+
+	// Health runs the dependency checks and publishes their outcome on the
+	// gRPC health service and over HTTP. Liveness only means the process is
+	// up; readiness means every dependency answered the last round.
+	Health struct {
+		server   *health.Server
+		logger   *zap.SugaredLogger
+		interval time.Duration
+		timeout  time.Duration
+
+		mu         sync.RWMutex
+		checks     []namedCheck
+		services   map[string][]string
+		errors     map[string]error
+		isChecked  bool
+		isShutdown bool
+	}
+
+	namedCheck struct {
+		name  string
+		check Check
+	}
+
+	report struct {
+		Status string            `json:"status"`
+		Checks map[string]string `json:"checks,omitempty"`
+	}
+)
+
+func New(cfg Config, logger *zap.SugaredLogger) *Health {
+	interval := cfg.Interval
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	server := health.NewServer()
+	server.SetServingStatus(LivenessService, healthpb.HealthCheckResponse_SERVING)
+
+	// Nothing is ready until the first round of checks passed.
+	server.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	server.SetServingStatus(ReadinessService, healthpb.HealthCheckResponse_NOT_SERVING)
+
+	return &Health{
+		server:   server,
+		logger:   logger,
+		interval: interval,
+		timeout:  timeout,
+		services: make(map[string][]string),
+		errors:   make(map[string]error),
+	}
+}
+
+// Server is the gRPC health service to register.
+func (h *Health) Server() *health.Server {
+	return h.server
+}
+
+// AddCheck adds a dependency. Checks must be added before Run.
+func (h *Health) AddCheck(name string, check Check) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.checks = append(h.checks, namedCheck{name: name, check: check})
+}
+
+// AddService reports a gRPC service as serving while the named checks
+// pass.
+func (h *Health) AddService(service string, checks ...string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.services[service] = checks
+	h.server.SetServingStatus(service, healthpb.HealthCheckResponse_NOT_SERVING)
+}
+
+// Run checks the dependencies right away and then every interval until ctx
+// is done.
+func (h *Health) Run(ctx context.Context) {
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+
+	for {
+		h.checkAll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Shutdown reports every service as not serving for good, so probes take
+// the instance out of rotation while it drains. Liveness is left alone.
+func (h *Health) Shutdown() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.isShutdown = true
+
+	h.server.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	h.server.SetServingStatus(ReadinessService, healthpb.HealthCheckResponse_NOT_SERVING)
+	for service := range h.services {
+		h.server.SetServingStatus(service, healthpb.HealthCheckResponse_NOT_SERVING)
+	}
+}
+
+// Register adds the HTTP probes to mux.
+func (h *Health) Register(mux *http.ServeMux) {
+	mux.HandleFunc("GET /healthz", h.Liveness)
+	mux.HandleFunc("GET /readyz", h.Readiness)
+}
+
+func (h *Health) Liveness(w http.ResponseWriter, r *http.Request) {
+	writeReport(w, http.StatusOK, report{Status: "ok"})
+}
+
+func (h *Health) Readiness(w http.ResponseWriter, r *http.Request) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	res := report{
+		Status: "ok",
+		Checks: make(map[string]string),
+	}
+	code := http.StatusOK
+
+	for _, c := range h.checks {
+		err, ok := h.errors[c.name]
+		switch {
+		case !h.isChecked:
+			res.Checks[c.name] = "pending"
+		case ok:
+			res.Checks[c.name] = err.Error()
+		default:
+			res.Checks[c.name] = "ok"
+		}
+	}
+
+	if !h.isReady() {
+		res.Status = "unavailable"
+		code = http.StatusServiceUnavailable
+	}
+
+	if h.isShutdown {
+		res.Status = "shutting down"
+	}
+
+	writeReport(w, code, res)
+}
+
+func (h *Health) checkAll(ctx context.Context) {
+	h.mu.RLock()
+	checks := h.checks
+	h.mu.RUnlock()
+
+	errs := make(map[string]error)
+	for _, c := range checks {
+		checkCtx, cancel := context.WithTimeout(ctx, h.timeout)
+		err := c.check(checkCtx)
+		cancel()
+
+		if err != nil {
+			errs[c.name] = err
+		}
+	}
+
+	if ctx.Err() != nil {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, c := range checks {
+		err, failed := errs[c.name]
+		_, wasFailed := h.errors[c.name]
+
+		if failed && !wasFailed {
+			h.logger.Warnw(constans.WARN,
+				"message", "health check failed",
+				"check", c.name,
+				"error", err.Error(),
+			)
+		}
+
+		if !failed && wasFailed {
+			h.logger.Infow(constans.INFO,
+				"message", "health check recovered",
+				"check", c.name,
+			)
+		}
+	}
+
+	h.errors = errs
+	h.isChecked = true
+
+	if h.isShutdown {
+		return
+	}
+
+	h.setStatus("", h.isReady())
+	h.setStatus(ReadinessService, h.isReady())
+	for service, names := range h.services {
+		h.setStatus(service, h.isPassing(names))
+	}
+}
+
+// isReady must be called with h.mu held.
+func (h *Health) isReady() bool {
+	return h.isChecked && !h.isShutdown && len(h.errors) == 0
+}
+
+func (h *Health) isPassing(names []string) bool {
+	for _, name := range names {
+		if _, ok := h.errors[name]; ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (h *Health) setStatus(service string, isServing bool) {
+	status := healthpb.HealthCheckResponse_NOT_SERVING
+	if isServing {
+		status = healthpb.HealthCheckResponse_SERVING
+	}
+
+	h.server.SetServingStatus(service, status)
+}
+
+func writeReport(w http.ResponseWriter, code int, res report) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(res)
+}
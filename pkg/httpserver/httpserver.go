@@ -0,0 +1,68 @@
+package httpserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+)
+
+type (
+	Config struct {
+		Port string `mapstructure:"port"`
+		// PublicURL is where clients reach the server, used in the links
+		// handed out to them. Defaults to http://localhost:<port>.
+		PublicURL string `mapstructure:"public_url"`
+	}
+
+	HttpServer struct {
+		*http.Server
+		Port string
+	}
+)
+
+const readHeaderTimeout time.Duration = 10
+
+func NewHttpServer(cfg Config, handler http.Handler) *HttpServer {
+	log.Println("Starting HTTP server...")
+
+	return &HttpServer{
+		Server: &http.Server{
+			Addr:              fmt.Sprintf(":%v", cfg.Port),
+			Handler:           handler,
+			ReadHeaderTimeout: readHeaderTimeout * time.Second,
+		},
+		Port: cfg.Port,
+	}
+}
+
+func (c Config) URL() string {
+	if c.PublicURL != "" {
+		return c.PublicURL
+	}
+
+	return fmt.Sprintf("http://localhost:%v", c.Port)
+}
+
+func (httpServer *HttpServer) Run() error {
+	log.Println("HTTP server listening on", httpServer.Port)
+	err := httpServer.Server.ListenAndServe()
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
+
+// Stop waits for the running requests until ctx is done, then closes the
+// connections left, like long exports or event streams.
+func (httpServer *HttpServer) Stop(ctx context.Context) error {
+	err := httpServer.Server.Shutdown(ctx)
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return httpServer.Server.Close()
+	}
+
+	return err
+}
@@ -0,0 +1,20 @@
+// Package identity carries the user a request is made for. The service does
+// not authenticate users itself; the ID comes from the x-user-id metadata,
+// set by the proxy in front of it, and is trusted as is.
+package identity
+
+import "context"
+
+type userIDKey struct{}
+
+// NewContext returns a copy of ctx carrying the user ID.
+func NewContext(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, userIDKey{}, userID)
+}
+
+// UserIDFromContext returns the user the request is made for, if known.
+func UserIDFromContext(ctx context.Context) (string, bool) {
+	userID, ok := ctx.Value(userIDKey{}).(string)
+
+	return userID, ok && userID != ""
+}
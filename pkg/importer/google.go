@@ -0,0 +1,61 @@
+package importer
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+)
+
+type (
+	googleTasksExport struct {
+		Items []googleTaskList `json:"items"`
+	}
+
+	googleTaskList struct {
+		Title string       `json:"title"`
+		Items []googleTask `json:"items"`
+	}
+
+	googleTask struct {
+		Title   string `json:"title"`
+		Notes   string `json:"notes"`
+		Status  string `json:"status"`
+		Due     string `json:"due"`
+		Deleted bool   `json:"deleted"`
+	}
+)
+
+// parseGoogleTasksJSON reads the Tasks.json file of a Google Takeout archive.
+// Google Tasks has no priorities and only keeps the date of a due time.
+func parseGoogleTasksJSON(r io.Reader) ([]List, error) {
+	var export googleTasksExport
+
+	err := json.NewDecoder(r).Decode(&export)
+	if err != nil {
+		return nil, malformed("google tasks json: %v", err)
+	}
+
+	var lists []List
+	for _, googleList := range export.Items {
+		list := List{Title: googleList.Title}
+
+		for _, googleTask := range googleList.Items {
+			if googleTask.Deleted {
+				continue
+			}
+
+			task := Task{
+				Title:     googleTask.Title,
+				Notes:     googleTask.Notes,
+				Completed: googleTask.Status == "completed",
+			}
+			task.DueAt, _ = parseTime(googleTask.Due, time.UTC)
+
+			list.Tasks = append(list.Tasks, task)
+		}
+
+		lists = append(lists, list)
+	}
+
+	return lists, nil
+}
@@ -0,0 +1,165 @@
+package importer
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// parseICal reads the VTODO components of an iCalendar file (RFC 5545). Each
+// calendar becomes a list named after X-WR-CALNAME, or name without one.
+func parseICal(name string, r io.Reader) ([]List, error) {
+	lines, err := unfoldICal(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		lists  []List
+		list   *List
+		task   *Task
+		nested int
+	)
+
+	for _, line := range lines {
+		prop, params, value, ok := splitICalLine(line)
+		if !ok {
+			return nil, malformed("ical line %q", line)
+		}
+
+		switch {
+		case prop == "BEGIN" && value == "VCALENDAR":
+			lists = append(lists, List{Title: name})
+			list = &lists[len(lists)-1]
+		case prop == "END" && value == "VCALENDAR":
+			list = nil
+		case list == nil:
+			return nil, malformed("ical content outside of VCALENDAR")
+		case prop == "BEGIN" && value == "VTODO":
+			task = &Task{}
+		case prop == "END" && value == "VTODO":
+			if task == nil {
+				return nil, malformed("unexpected END:VTODO")
+			}
+
+			list.Tasks = append(list.Tasks, *task)
+			task = nil
+		case prop == "BEGIN":
+			nested++
+		case prop == "END":
+			nested--
+		case nested > 0:
+			// Alarms and other sub-components are not imported.
+		case task == nil:
+			if prop == "X-WR-CALNAME" {
+				list.Title = unescapeICalText(value)
+			}
+		default:
+			setICalTaskProperty(task, prop, params, value)
+		}
+	}
+
+	if list != nil || task != nil {
+		return nil, malformed("ical file ends inside a component")
+	}
+
+	return lists, nil
+}
+
+func setICalTaskProperty(task *Task, prop string, params map[string]string, value string) {
+	switch prop {
+	case "SUMMARY":
+		task.Title = unescapeICalText(value)
+	case "DESCRIPTION":
+		task.Notes = unescapeICalText(value)
+	case "PRIORITY":
+		task.Priority = ICalPriority(value)
+	case "STATUS":
+		task.Completed = task.Completed || value == "COMPLETED"
+	case "COMPLETED":
+		task.Completed = true
+	case "DUE":
+		loc, err := time.LoadLocation(params["TZID"])
+		if err != nil {
+			loc = time.UTC
+		}
+
+		task.DueAt, _ = parseTime(value, loc)
+	}
+}
+
+// unfoldICal joins folded lines back together.
+func unfoldICal(r io.Reader) ([]string, error) {
+	var lines []string
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "" {
+			continue
+		}
+
+		if (line[0] == ' ' || line[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+
+		lines = append(lines, line)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, malformed("ical: %v", err)
+	}
+
+	return lines, nil
+}
+
+// splitICalLine splits "NAME;PARAM=VALUE:value" into its parts. A colon
+// inside a quoted parameter value does not end the name.
+func splitICalLine(line string) (string, map[string]string, string, bool) {
+	quoted := false
+	for i, r := range line {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case r == ':' && !quoted:
+			parts := strings.Split(line[:i], ";")
+			params := make(map[string]string, len(parts)-1)
+			for _, param := range parts[1:] {
+				key, value, _ := strings.Cut(param, "=")
+				params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+			}
+
+			return strings.ToUpper(parts[0]), params, line[i+1:], true
+		}
+	}
+
+	return "", nil, "", false
+}
+
+func unescapeICalText(value string) string {
+	return strings.NewReplacer(`\n`, "\n", `\N`, "\n", `\,`, ",", `\;`, ";", `\\`, `\`).Replace(value)
+}
+
+// ICalPriority maps the iCalendar priorities 1-4 to high, 5 to medium and
+// 6-9 to low; 0 is undefined.
+func ICalPriority(value string) int {
+	priority, err := strconv.Atoi(value)
+	if err != nil {
+		return PriorityNone
+	}
+
+	switch {
+	case priority >= 1 && priority <= 4:
+		return PriorityHigh
+	case priority == 5:
+		return PriorityMedium
+	case priority >= 6 && priority <= 9:
+		return PriorityLow
+	default:
+		return PriorityNone
+	}
+}
@@ -0,0 +1,138 @@
+// Package importer reads task exports of other apps into a neutral list of
+// tasks. It knows nothing about how the service stores them.
+package importer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+const (
+	FormatJSON              = "json"
+	FormatTodoistCSV        = "todoist_csv"
+	FormatMicrosoftTodoJSON = "microsoft_todo_json"
+	FormatGoogleTasksJSON   = "google_tasks_json"
+	FormatICal              = "ical"
+)
+
+// Priorities every format is mapped to, from none to high.
+const (
+	PriorityNone = iota
+	PriorityLow
+	PriorityMedium
+	PriorityHigh
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("importer: unsupported format")
+	ErrMalformed         = errors.New("importer: malformed file")
+)
+
+type (
+	List struct {
+		Title string
+		// Type and Texts are only set by files exported by this service.
+		Type  string
+		Tasks []Task
+		Texts []string
+	}
+
+	Task struct {
+		Title     string
+		Notes     string
+		Priority  int
+		DueAt     *time.Time
+		Completed bool
+	}
+)
+
+// Parse reads an export in the given format. name is used as the list title
+// when the file does not carry one, e.g. a Todoist project export.
+func Parse(format, name string, r io.Reader) ([]List, error) {
+	var (
+		lists []List
+		err   error
+	)
+
+	switch format {
+	case FormatJSON:
+		lists, err = parseJSON(r)
+	case FormatTodoistCSV:
+		lists, err = parseTodoistCSV(name, r)
+	case FormatMicrosoftTodoJSON:
+		lists, err = parseMicrosoftTodoJSON(r)
+	case FormatGoogleTasksJSON:
+		lists, err = parseGoogleTasksJSON(r)
+	case FormatICal:
+		lists, err = parseICal(name, r)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedFormat, format)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Drop what cannot become a task and lists left empty by it. Lists of
+	// this service are kept as they are, empty or not.
+	var res []List
+	for _, list := range lists {
+		list.Title = strings.TrimSpace(list.Title)
+		if list.Title == "" {
+			list.Title = name
+		}
+
+		tasks := list.Tasks[:0]
+		for _, task := range list.Tasks {
+			task.Title = strings.TrimSpace(task.Title)
+			task.Notes = strings.TrimSpace(task.Notes)
+			if task.Title != "" {
+				tasks = append(tasks, task)
+			}
+		}
+		list.Tasks = tasks
+
+		if len(list.Tasks) > 0 || list.Type != "" {
+			res = append(res, list)
+		}
+	}
+
+	return res, nil
+}
+
+func malformed(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s", ErrMalformed, fmt.Sprintf(format, args...))
+}
+
+// parseTime accepts the layouts seen in the supported exports. Values without
+// a zone are read in loc.
+func parseTime(value string, loc *time.Location) (*time.Time, bool) {
+	layouts := []string{
+		time.RFC3339Nano,
+		"2006-01-02T15:04:05.9999999",
+		"2006-01-02T15:04:05",
+		"2006-01-02 15:04",
+		"2006-01-02",
+		"20060102T150405Z",
+		"20060102T150405",
+		"20060102",
+		"Jan 2 2006 15:04",
+		"Jan 2 2006",
+		"2 Jan 2006 15:04",
+		"2 Jan 2006",
+	}
+
+	value = strings.TrimSpace(value)
+	for _, layout := range layouts {
+		t, err := time.ParseInLocation(layout, value, loc)
+		if err == nil {
+			t = t.UTC()
+			return &t, true
+		}
+	}
+
+	return nil, false
+}
@@ -0,0 +1,73 @@
+package importer
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/digisata/todo-service/pkg/exporter"
+)
+
+type (
+	jsonDocument struct {
+		Version    int            `json:"version"`
+		Activities []jsonActivity `json:"activities"`
+	}
+
+	jsonActivity struct {
+		Title string     `json:"title"`
+		Type  string     `json:"type"`
+		Tasks []jsonTask `json:"tasks"`
+		Texts []jsonText `json:"texts"`
+	}
+
+	jsonTask struct {
+		Title     string     `json:"title"`
+		Completed bool       `json:"completed"`
+		Priority  int        `json:"priority"`
+		DueAt     *time.Time `json:"due_at"`
+	}
+
+	jsonText struct {
+		Text string `json:"text"`
+	}
+)
+
+// parseJSON reads a file written by the JSON exporter of this service.
+func parseJSON(r io.Reader) ([]List, error) {
+	var document jsonDocument
+
+	err := json.NewDecoder(r).Decode(&document)
+	if err != nil {
+		return nil, malformed("json: %v", err)
+	}
+
+	if document.Version < 1 || document.Version > exporter.Version {
+		return nil, malformed("json export version %d is not supported", document.Version)
+	}
+
+	var lists []List
+	for _, activity := range document.Activities {
+		list := List{
+			Title: activity.Title,
+			Type:  activity.Type,
+		}
+
+		for _, task := range activity.Tasks {
+			list.Tasks = append(list.Tasks, Task{
+				Title:     task.Title,
+				Completed: task.Completed,
+				Priority:  task.Priority,
+				DueAt:     task.DueAt,
+			})
+		}
+
+		for _, text := range activity.Texts {
+			list.Texts = append(list.Texts, text.Text)
+		}
+
+		lists = append(lists, list)
+	}
+
+	return lists, nil
+}
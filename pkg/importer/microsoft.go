@@ -0,0 +1,110 @@
+package importer
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"time"
+)
+
+type (
+	microsoftTodoExport struct {
+		Value []microsoftTodoList `json:"value"`
+		Lists []microsoftTodoList `json:"lists"`
+	}
+
+	microsoftTodoList struct {
+		DisplayName string              `json:"displayName"`
+		Tasks       []microsoftTodoTask `json:"tasks"`
+	}
+
+	microsoftTodoTask struct {
+		Title       string `json:"title"`
+		Status      string `json:"status"`
+		Importance  string `json:"importance"`
+		DueDateTime *struct {
+			DateTime string `json:"dateTime"`
+			TimeZone string `json:"timeZone"`
+		} `json:"dueDateTime"`
+		Body *struct {
+			Content string `json:"content"`
+		} `json:"body"`
+		ChecklistItems []struct {
+			DisplayName string `json:"displayName"`
+			IsChecked   bool   `json:"isChecked"`
+		} `json:"checklistItems"`
+	}
+)
+
+// parseMicrosoftTodoJSON reads task lists in the Microsoft Graph shape, with
+// each list's tasks expanded. The lists may be a bare array or wrapped in
+// "value" or "lists". Checklist steps are kept as lines of the task notes.
+func parseMicrosoftTodoJSON(r io.Reader) ([]List, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var export microsoftTodoExport
+	if strings.HasPrefix(strings.TrimSpace(string(data)), "[") {
+		err = json.Unmarshal(data, &export.Value)
+	} else {
+		err = json.Unmarshal(data, &export)
+	}
+
+	if err != nil {
+		return nil, malformed("microsoft to do json: %v", err)
+	}
+
+	var lists []List
+	for _, msList := range append(export.Value, export.Lists...) {
+		list := List{Title: msList.DisplayName}
+
+		for _, msTask := range msList.Tasks {
+			task := Task{
+				Title:     msTask.Title,
+				Completed: msTask.Status == "completed",
+				Priority:  microsoftTodoPriority(msTask.Importance),
+			}
+
+			if msTask.Body != nil {
+				task.Notes = msTask.Body.Content
+			}
+
+			for _, item := range msTask.ChecklistItems {
+				mark := "[ ]"
+				if item.IsChecked {
+					mark = "[x]"
+				}
+
+				task.Notes += "\n- " + mark + " " + item.DisplayName
+			}
+
+			if msTask.DueDateTime != nil {
+				loc, err := time.LoadLocation(msTask.DueDateTime.TimeZone)
+				if err != nil {
+					loc = time.UTC
+				}
+
+				task.DueAt, _ = parseTime(msTask.DueDateTime.DateTime, loc)
+			}
+
+			list.Tasks = append(list.Tasks, task)
+		}
+
+		lists = append(lists, list)
+	}
+
+	return lists, nil
+}
+
+func microsoftTodoPriority(importance string) int {
+	switch importance {
+	case "high":
+		return PriorityHigh
+	case "low":
+		return PriorityLow
+	default:
+		return PriorityNone
+	}
+}
@@ -0,0 +1,111 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// parseTodoistCSV reads a project exported from Todoist. Each section becomes
+// its own list and note rows are appended to the notes of the task above them.
+// Todoist numbers priorities from 1 (highest) to 4 (none).
+func parseTodoistCSV(name string, r io.Reader) ([]List, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, malformed("empty todoist file")
+	}
+
+	if err != nil {
+		return nil, malformed("todoist header: %v", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, column := range header {
+		column = strings.TrimPrefix(column, "\ufeff")
+		columns[strings.ToUpper(strings.TrimSpace(column))] = i
+	}
+
+	for _, column := range []string{"TYPE", "CONTENT"} {
+		if _, ok := columns[column]; !ok {
+			return nil, malformed("todoist file has no %s column", column)
+		}
+	}
+
+	field := func(record []string, column string) string {
+		i, ok := columns[column]
+		if !ok || i >= len(record) {
+			return ""
+		}
+
+		return strings.TrimSpace(record[i])
+	}
+
+	lists := []List{{Title: name}}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, malformed("todoist row: %v", err)
+		}
+
+		list := &lists[len(lists)-1]
+
+		switch strings.ToLower(field(record, "TYPE")) {
+		case "section":
+			lists = append(lists, List{Title: name + " / " + field(record, "CONTENT")})
+		case "task":
+			task := Task{
+				Title:    field(record, "CONTENT"),
+				Notes:    field(record, "DESCRIPTION"),
+				Priority: todoistPriority(field(record, "PRIORITY")),
+			}
+
+			loc, err := time.LoadLocation(field(record, "TIMEZONE"))
+			if err != nil {
+				loc = time.UTC
+			}
+
+			// Recurring dates such as "every monday" have no single due date.
+			task.DueAt, _ = parseTime(field(record, "DATE"), loc)
+
+			list.Tasks = append(list.Tasks, task)
+		case "note":
+			if len(list.Tasks) == 0 {
+				continue
+			}
+
+			task := &list.Tasks[len(list.Tasks)-1]
+			task.Notes = strings.TrimSpace(task.Notes + "\n\n" + field(record, "CONTENT"))
+		}
+	}
+
+	return lists, nil
+}
+
+func todoistPriority(value string) int {
+	priority, err := strconv.Atoi(value)
+	if err != nil {
+		return PriorityNone
+	}
+
+	switch priority {
+	case 1:
+		return PriorityHigh
+	case 2:
+		return PriorityMedium
+	case 3:
+		return PriorityLow
+	default:
+		return PriorityNone
+	}
+}
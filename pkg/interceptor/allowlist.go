@@ -0,0 +1,123 @@
+package interceptor
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Allowlist refuses calls to the guarded methods, matched by prefix, unless
+// the caller presents a verified client certificate with an allowed common
+// name or connects from an allowed network. With neither configured only
+// loopback callers are allowed. Both are taken from the connection itself,
+// never from metadata like x-user-id or x-forwarded-for, so they cannot be
+// claimed by the caller.
+type Allowlist struct {
+	methods  []string
+	clients  []string
+	networks []*net.IPNet
+}
+
+// NewAllowlist parses networks as CIDRs like 10.0.0.0/8. Clients are the
+// common names of client certificates.
+func NewAllowlist(methods, clients, networks []string) (*Allowlist, error) {
+	a := &Allowlist{
+		methods: methods,
+		clients: clients,
+	}
+
+	for _, network := range networks {
+		_, ipNet, err := net.ParseCIDR(network)
+		if err != nil {
+			return nil, fmt.Errorf("allowlist network %q: %w", network, err)
+		}
+
+		a.networks = append(a.networks, ipNet)
+	}
+
+	return a, nil
+}
+
+func (a *Allowlist) Unary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	err := a.allow(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+func (a *Allowlist) Stream(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	err := a.allow(stream.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+
+	return handler(srv, stream)
+}
+
+func (a *Allowlist) allow(ctx context.Context, method string) error {
+	guarded := slices.ContainsFunc(a.methods, func(prefix string) bool {
+		return strings.HasPrefix(method, prefix)
+	})
+	if !guarded {
+		return nil
+	}
+
+	if name, ok := peerCommonName(ctx); ok && slices.Contains(a.clients, name) {
+		return nil
+	}
+
+	ip := peerIP(ctx)
+	if ip != nil {
+		if len(a.clients) == 0 && len(a.networks) == 0 && ip.IsLoopback() {
+			return nil
+		}
+
+		for _, network := range a.networks {
+			if network.Contains(ip) {
+				return nil
+			}
+		}
+	}
+
+	return status.Errorf(codes.PermissionDenied, "%s is not allowed for this caller", method)
+}
+
+// peerCommonName is the common name of the client certificate, if the TLS
+// handshake verified one against the client CAs.
+func peerCommonName(ctx context.Context) (string, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "", false
+	}
+
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+		return "", false
+	}
+
+	return info.State.VerifiedChains[0][0].Subject.CommonName, true
+}
+
+func peerIP(ctx context.Context) net.IP {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return nil
+	}
+
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		host = p.Addr.String()
+	}
+
+	return net.ParseIP(host)
+}
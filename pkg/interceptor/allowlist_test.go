@@ -0,0 +1,80 @@
+package interceptor
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const reflectionMethod = "/grpc.reflection.v1.ServerReflection/ServerReflectionInfo"
+
+func peerContext(ip string, verified, presented string) context.Context {
+	var state tls.ConnectionState
+	if presented != "" {
+		state.PeerCertificates = []*x509.Certificate{{Subject: pkix.Name{CommonName: presented}}}
+	}
+
+	if verified != "" {
+		state.VerifiedChains = [][]*x509.Certificate{{{Subject: pkix.Name{CommonName: verified}}}}
+	}
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr:     &net.TCPAddr{IP: net.ParseIP(ip), Port: 50000},
+		AuthInfo: credentials.TLSInfo{State: state},
+	})
+
+	// Metadata is set by the caller and grants nothing.
+	return metadata.NewIncomingContext(ctx, metadata.Pairs("x-user-id", "admin"))
+}
+
+func TestAllowlist(t *testing.T) {
+	allowlist, err := NewAllowlist([]string{"/grpc.reflection."}, []string{"admin"}, []string{"10.0.0.0/8"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loopbackOnly, err := NewAllowlist([]string{"/grpc.reflection."}, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name      string
+		allowlist *Allowlist
+		method    string
+		ctx       context.Context
+		allowed   bool
+	}{
+		{name: "unguarded method", allowlist: allowlist, method: "/todo.TaskService/GetAll", ctx: peerContext("203.0.113.7", "", ""), allowed: true},
+		{name: "verified client", allowlist: allowlist, method: reflectionMethod, ctx: peerContext("203.0.113.7", "admin", "admin"), allowed: true},
+		{name: "other client", allowlist: allowlist, method: reflectionMethod, ctx: peerContext("203.0.113.7", "someone", "someone"), allowed: false},
+		{name: "unverified certificate", allowlist: allowlist, method: reflectionMethod, ctx: peerContext("203.0.113.7", "", "admin"), allowed: false},
+		{name: "x-user-id only", allowlist: allowlist, method: reflectionMethod, ctx: peerContext("203.0.113.7", "", ""), allowed: false},
+		{name: "allowed network", allowlist: allowlist, method: reflectionMethod, ctx: peerContext("10.1.2.3", "", ""), allowed: true},
+		{name: "loopback with lists", allowlist: allowlist, method: reflectionMethod, ctx: peerContext("127.0.0.1", "", ""), allowed: false},
+		{name: "loopback without lists", allowlist: loopbackOnly, method: reflectionMethod, ctx: peerContext("127.0.0.1", "", ""), allowed: true},
+		{name: "remote without lists", allowlist: loopbackOnly, method: reflectionMethod, ctx: peerContext("203.0.113.7", "", ""), allowed: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.allowlist.allow(tc.ctx, tc.method)
+			if tc.allowed && err != nil {
+				t.Errorf("refused: %v", err)
+			}
+
+			if !tc.allowed && status.Code(err) != codes.PermissionDenied {
+				t.Errorf("got %v, want %v", err, codes.PermissionDenied)
+			}
+		})
+	}
+}
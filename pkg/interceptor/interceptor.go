@@ -2,14 +2,36 @@ package interceptor
 
 import (
 	"context"
+	"math/rand/v2"
+	"sync/atomic"
+	"time"
 
 	"github.com/digisata/todo-service/pkg/constans"
+	"github.com/digisata/todo-service/pkg/identity"
+	"github.com/digisata/todo-service/pkg/logging"
+	"github.com/digisata/todo-service/pkg/ratelimit"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	RequestIDHeader = "x-request-id"
+	UserIDHeader    = "x-user-id"
+
+	maxRequestIDLength = 128
 )
 
 type InterceptorManager interface {
 	Logger(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error)
+	StreamLogger(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error
+	RateLimit(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error)
+	StreamRateLimit(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error
 	ClientRequestLoggerInterceptor() func(
 		ctx context.Context,
 		method string,
@@ -19,38 +41,102 @@ type InterceptorManager interface {
 		invoker grpc.UnaryInvoker,
 		opts ...grpc.CallOption,
 	) error
+	ClientStreamLoggerInterceptor() func(
+		ctx context.Context,
+		desc *grpc.StreamDesc,
+		cc *grpc.ClientConn,
+		method string,
+		streamer grpc.Streamer,
+		opts ...grpc.CallOption,
+	) (grpc.ClientStream, error)
 }
 
 type interceptorManager struct {
-	logger *zap.SugaredLogger
+	logger             *zap.SugaredLogger
+	successSampleRatio float64
+	limiter            *ratelimit.Limiter
 }
 
-func NewInterceptorManager(logger *zap.SugaredLogger) *interceptorManager {
+// NewInterceptorManager returns the interceptors of the server. A nil
+// limiter turns rate limiting off.
+func NewInterceptorManager(logger *zap.SugaredLogger, cfg logging.Config, limiter *ratelimit.Limiter) *interceptorManager {
+	ratio := cfg.SuccessSampleRatio
+	if ratio <= 0 {
+		ratio = 1
+	}
+
 	return &interceptorManager{
-		logger: logger,
+		logger:             logger,
+		successSampleRatio: ratio,
+		limiter:            limiter,
 	}
 }
 
+// Logger logs one line per call once it finished. The call gets a request
+// ID, taken from the x-request-id metadata when the caller sent one, and a
+// logger holding it and the trace IDs, which FromContext returns downstream.
 func (im interceptorManager) Logger(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	start := time.Now()
+
+	ctx, logger := im.newRequestContext(ctx, info.FullMethod)
+
 	reply, err := handler(ctx, req)
-	if err != nil {
-		im.logger.Errorw(constans.ERROR,
-			"method", info.FullMethod,
-			"request", req,
-			"error", err.Error(),
-		)
 
+	code := status.Code(err)
+	if code == codes.OK && !im.isSampled() {
 		return reply, err
 	}
 
-	im.logger.Infow(constans.INFO,
-		"method", info.FullMethod,
-		"request", req,
-		"error", nil,
-	)
+	fields := []interface{}{
+		"code", code.String(),
+		"latency", time.Since(start),
+		"peer", peerAddr(ctx),
+		"user", userIDField(ctx),
+		redactedField("request", req),
+	}
+	if err != nil {
+		fields = append(fields, "error", err.Error())
+	}
+
+	logByCode(logger, code, fields...)
 
 	return reply, err
+}
+
+// StreamLogger is the stream variant of Logger. The line is written when the
+// stream ends and counts the messages of both directions instead of holding
+// the request.
+func (im interceptorManager) StreamLogger(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
 
+	ctx, logger := im.newRequestContext(stream.Context(), info.FullMethod)
+	wrapped := &serverStream{
+		ServerStream: stream,
+		ctx:          ctx,
+	}
+
+	err := handler(srv, wrapped)
+
+	code := status.Code(err)
+	if code == codes.OK && !im.isSampled() {
+		return err
+	}
+
+	fields := []interface{}{
+		"code", code.String(),
+		"latency", time.Since(start),
+		"peer", peerAddr(ctx),
+		"user", userIDField(ctx),
+		"received", wrapped.received.Load(),
+		"sent", wrapped.sent.Load(),
+	}
+	if err != nil {
+		fields = append(fields, "error", err.Error())
+	}
+
+	logByCode(logger, code, fields...)
+
+	return err
 }
 
 func (im interceptorManager) ClientRequestLoggerInterceptor() func(
@@ -71,13 +157,185 @@ func (im interceptorManager) ClientRequestLoggerInterceptor() func(
 		invoker grpc.UnaryInvoker,
 		opts ...grpc.CallOption,
 	) error {
+		start := time.Now()
+
+		// Calls made while serving a request keep its ID
+		if requestID, ok := logging.RequestIDFromContext(ctx); ok {
+			ctx = metadata.AppendToOutgoingContext(ctx, RequestIDHeader, requestID)
+		}
+
 		err := invoker(ctx, method, req, reply, cc, opts...)
-		im.logger.Infow(constans.INFO,
+
+		code := status.Code(err)
+		fields := []interface{}{
 			"method", method,
-			"request", req,
-			"error", nil,
-		)
+			"code", code.String(),
+			"latency", time.Since(start),
+			"target", cc.Target(),
+		}
+		if err != nil {
+			fields = append(fields, "error", err.Error())
+		}
+
+		logByCode(logging.FromContext(ctx), code, fields...)
 
 		return err
 	}
 }
+
+// ClientStreamLoggerInterceptor forwards the request ID on outgoing streams
+// and logs the ones that could not be opened. Their end is up to the caller.
+func (im interceptorManager) ClientStreamLoggerInterceptor() func(
+	ctx context.Context,
+	desc *grpc.StreamDesc,
+	cc *grpc.ClientConn,
+	method string,
+	streamer grpc.Streamer,
+	opts ...grpc.CallOption,
+) (grpc.ClientStream, error) {
+	return func(
+		ctx context.Context,
+		desc *grpc.StreamDesc,
+		cc *grpc.ClientConn,
+		method string,
+		streamer grpc.Streamer,
+		opts ...grpc.CallOption,
+	) (grpc.ClientStream, error) {
+		if requestID, ok := logging.RequestIDFromContext(ctx); ok {
+			ctx = metadata.AppendToOutgoingContext(ctx, RequestIDHeader, requestID)
+		}
+
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			logByCode(logging.FromContext(ctx), status.Code(err),
+				"method", method,
+				"code", status.Code(err).String(),
+				"target", cc.Target(),
+				"error", err.Error(),
+			)
+		}
+
+		return stream, err
+	}
+}
+
+// newRequestContext puts the request ID, the user and the request-scoped
+// logger on ctx and echoes the ID back in the response headers.
+func (im interceptorManager) newRequestContext(ctx context.Context, method string) (context.Context, *zap.SugaredLogger) {
+	requestID := requestIDFromMetadata(ctx)
+	grpc.SetHeader(ctx, metadata.Pairs(RequestIDHeader, requestID))
+
+	fields := append([]interface{}{
+		"request_id", requestID,
+		"method", method,
+	}, traceFields(ctx)...)
+	logger := im.logger.With(fields...)
+
+	ctx = logging.NewRequestIDContext(ctx, requestID)
+	ctx = logging.NewContext(ctx, logger)
+	if user := userID(ctx); user != "" {
+		ctx = identity.NewContext(ctx, user)
+	}
+
+	return ctx, logger
+}
+
+// serverStream hands the request context to the handler and counts the
+// messages. Receiving and sending may run on different goroutines.
+type serverStream struct {
+	grpc.ServerStream
+	ctx      context.Context
+	received atomic.Int64
+	sent     atomic.Int64
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *serverStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil {
+		s.received.Add(1)
+	}
+
+	return err
+}
+
+func (s *serverStream) SendMsg(m interface{}) error {
+	err := s.ServerStream.SendMsg(m)
+	if err == nil {
+		s.sent.Add(1)
+	}
+
+	return err
+}
+
+func (im interceptorManager) isSampled() bool {
+	return im.successSampleRatio >= 1 || rand.Float64() < im.successSampleRatio
+}
+
+// logByCode logs caller mistakes as warnings and server faults as errors.
+func logByCode(logger *zap.SugaredLogger, code codes.Code, fields ...interface{}) {
+	switch code {
+	case codes.OK:
+		logger.Infow(constans.INFO, fields...)
+	case codes.Canceled, codes.InvalidArgument, codes.NotFound, codes.AlreadyExists,
+		codes.PermissionDenied, codes.Unauthenticated, codes.ResourceExhausted,
+		codes.FailedPrecondition, codes.Aborted, codes.OutOfRange:
+		logger.Warnw(constans.WARN, fields...)
+	default:
+		logger.Errorw(constans.ERROR, fields...)
+	}
+}
+
+func requestIDFromMetadata(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, requestID := range md.Get(RequestIDHeader) {
+		if requestID != "" && len(requestID) <= maxRequestIDLength {
+			return requestID
+		}
+	}
+
+	return uuid.NewString()
+}
+
+// userID is the x-user-id metadata. A user_id field in the request names
+// the user it is about, not the caller, so it is never taken as the identity.
+func userID(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(UserIDHeader); len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
+}
+
+func userIDField(ctx context.Context) string {
+	user, _ := identity.UserIDFromContext(ctx)
+
+	return user
+}
+
+func peerAddr(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+
+	return p.Addr.String()
+}
+
+// traceFields returns the ids of the span in ctx as log fields, so log lines
+// can be looked up from a trace and back.
+func traceFields(ctx context.Context) []interface{} {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.IsValid() {
+		return nil
+	}
+
+	return []interface{}{
+		"trace_id", spanContext.TraceID().String(),
+		"span_id", spanContext.SpanID().String(),
+	}
+}
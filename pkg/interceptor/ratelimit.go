@@ -0,0 +1,104 @@
+package interceptor
+
+import (
+	"context"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/digisata/todo-service/pkg/identity"
+	"github.com/digisata/todo-service/pkg/ratelimit"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+const RetryAfterHeader = "retry-after"
+
+// RateLimit refuses calls over the configured rates with RESOURCE_EXHAUSTED.
+// The wait is sent as retry-after metadata in seconds and as RetryInfo in
+// the status details.
+func (im interceptorManager) RateLimit(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	err = im.allow(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+// StreamRateLimit is the stream variant of RateLimit. Only opening the
+// stream takes a token, not its messages.
+func (im interceptorManager) StreamRateLimit(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	err := im.allow(stream.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+
+	return handler(srv, stream)
+}
+
+func (im interceptorManager) allow(ctx context.Context, method string) error {
+	if im.limiter == nil {
+		return nil
+	}
+
+	user, _ := identity.UserIDFromContext(ctx)
+	ok, delay := im.limiter.Allow(ratelimit.Caller{
+		Method: method,
+		UserID: user,
+		IP:     clientIP(ctx),
+	})
+	if ok {
+		return nil
+	}
+
+	seconds := int(math.Ceil(delay.Seconds()))
+	grpc.SetHeader(ctx, metadata.Pairs(RetryAfterHeader, strconv.Itoa(seconds)))
+
+	st, err := status.New(codes.ResourceExhausted, "rate limit exceeded").WithDetails(&errdetails.RetryInfo{
+		RetryDelay: durationpb.New(time.Duration(seconds) * time.Second),
+	})
+	if err != nil {
+		return status.Error(codes.ResourceExhausted, "rate limit exceeded")
+	}
+
+	return st.Err()
+}
+
+// clientIP is the address of the peer. Calls from the same host, like the
+// HTTP gateway, are attributed to the last x-forwarded-for address: the one
+// the gateway appended for its own peer. The entries before it come from the
+// client and can be anything.
+func clientIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		host = p.Addr.String()
+	}
+
+	if ip := net.ParseIP(host); ip == nil || !ip.IsLoopback() {
+		return host
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get("x-forwarded-for"); len(values) > 0 {
+		last := values[len(values)-1]
+		forwarded := strings.TrimSpace(last[strings.LastIndex(last, ",")+1:])
+		if forwarded != "" {
+			return forwarded
+		}
+	}
+
+	return host
+}
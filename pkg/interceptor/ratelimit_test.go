@@ -0,0 +1,39 @@
+package interceptor
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func TestClientIP(t *testing.T) {
+	cases := []struct {
+		name      string
+		peer      string
+		forwarded []string
+		want      string
+	}{
+		{name: "remote peer", peer: "203.0.113.7", forwarded: []string{"198.51.100.1"}, want: "203.0.113.7"},
+		{name: "gateway", peer: "127.0.0.1", forwarded: []string{"198.51.100.1"}, want: "198.51.100.1"},
+		{name: "spoofed by the client", peer: "127.0.0.1", forwarded: []string{"10.0.0.1, 198.51.100.1"}, want: "198.51.100.1"},
+		{name: "several headers", peer: "127.0.0.1", forwarded: []string{"10.0.0.1", "198.51.100.1"}, want: "198.51.100.1"},
+		{name: "not forwarded", peer: "127.0.0.1", want: "127.0.0.1"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := peer.NewContext(context.Background(), &peer.Peer{
+				Addr: &net.TCPAddr{IP: net.ParseIP(tc.peer), Port: 50000},
+			})
+			ctx = metadata.NewIncomingContext(ctx, metadata.MD{"x-forwarded-for": tc.forwarded})
+
+			got := clientIP(ctx)
+			if got != tc.want {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package interceptor
+
+import (
+	"encoding/json"
+
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const redacted = "[REDACTED]"
+
+// redactedFields hold note bodies, comments and credentials. Bytes fields,
+// like upload chunks, are always left out.
+var redactedFields = map[protoreflect.Name]bool{
+	"text":       true,
+	"plain_text": true,
+	"body":       true,
+	"token":      true,
+	"password":   true,
+	"secret":     true,
+	"feed_url":   true,
+	"caldav_url": true,
+}
+
+// redactedField logs msg as JSON with the sensitive fields replaced.
+func redactedField(key string, msg interface{}) zap.Field {
+	m, ok := msg.(proto.Message)
+	if !ok {
+		return zap.Any(key, msg)
+	}
+
+	m = proto.Clone(m)
+	redactMessage(m.ProtoReflect())
+
+	b, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(m)
+	if err != nil {
+		return zap.String(key, redacted)
+	}
+
+	return zap.Reflect(key, json.RawMessage(b))
+}
+
+func redactMessage(m protoreflect.Message) {
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.Kind() == protoreflect.BytesKind:
+			m.Clear(fd)
+		case redactedFields[fd.Name()] && fd.Kind() == protoreflect.StringKind && !fd.IsList() && !fd.IsMap():
+			m.Set(fd, protoreflect.ValueOfString(redacted))
+		case fd.IsList() && fd.Kind() == protoreflect.MessageKind:
+			list := v.List()
+			for i := 0; i < list.Len(); i++ {
+				redactMessage(list.Get(i).Message())
+			}
+		case fd.IsMap() && fd.MapValue().Kind() == protoreflect.MessageKind:
+			v.Map().Range(func(_ protoreflect.MapKey, mv protoreflect.Value) bool {
+				redactMessage(mv.Message())
+				return true
+			})
+		case fd.Kind() == protoreflect.MessageKind && !fd.IsList() && !fd.IsMap():
+			redactMessage(v.Message())
+		}
+
+		return true
+	})
+}
@@ -0,0 +1,79 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type (
+	Config struct {
+		// Level is debug, info, warn or error; info when unset.
+		Level string `mapstructure:"level"`
+		// Encoding is json or console; json when unset.
+		Encoding string `mapstructure:"encoding"`
+		// SuccessSampleRatio of successful calls the request log keeps,
+		// between 0 and 1; 1 when unset. Failed calls are always logged.
+		SuccessSampleRatio float64 `mapstructure:"success_sample_ratio"`
+	}
+
+	loggerKey    struct{}
+	requestIDKey struct{}
+)
+
+// New builds the process logger. It is also installed as the zap global,
+// which FromContext falls back to outside of a request.
+func New(cfg Config) (*zap.Logger, error) {
+	zapCfg := zap.NewProductionConfig()
+
+	if cfg.Level != "" {
+		level, err := zapcore.ParseLevel(cfg.Level)
+		if err != nil {
+			return nil, fmt.Errorf("logging - New: %v", err)
+		}
+
+		zapCfg.Level = zap.NewAtomicLevelAt(level)
+	}
+
+	if cfg.Encoding != "" {
+		zapCfg.Encoding = cfg.Encoding
+	}
+
+	logger, err := zapCfg.Build()
+	if err != nil {
+		return nil, fmt.Errorf("logging - New: %v", err)
+	}
+
+	zap.ReplaceGlobals(logger)
+
+	return logger, nil
+}
+
+// NewContext returns a copy of ctx carrying logger.
+func NewContext(ctx context.Context, logger *zap.SugaredLogger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// FromContext returns the request-scoped logger, which already holds the
+// request ID and trace IDs, or the global logger outside of a request.
+func FromContext(ctx context.Context) *zap.SugaredLogger {
+	if logger, ok := ctx.Value(loggerKey{}).(*zap.SugaredLogger); ok {
+		return logger
+	}
+
+	return zap.S()
+}
+
+// NewRequestIDContext returns a copy of ctx carrying the request ID.
+func NewRequestIDContext(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext returns the ID of the request being served, if any.
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	requestID, ok := ctx.Value(requestIDKey{}).(string)
+
+	return requestID, ok
+}
@@ -0,0 +1,64 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+type (
+	Config struct {
+		// Address the metrics listener binds to, like ":9103". Metrics are
+		// not served when it is empty.
+		Address string `mapstructure:"address"`
+	}
+
+	// MetricsServer serves the default Prometheus registry on its own
+	// listener, so scrapes never go through the public ports.
+	MetricsServer struct {
+		*http.Server
+	}
+)
+
+const (
+	metricsPath                     = "/metrics"
+	readHeaderTimeout time.Duration = 10
+)
+
+func NewMetricsServer(cfg Config) *MetricsServer {
+	log.Println("Starting metrics server...")
+
+	mux := http.NewServeMux()
+	mux.Handle("GET "+metricsPath, promhttp.Handler())
+
+	return &MetricsServer{
+		Server: &http.Server{
+			Addr:              cfg.Address,
+			Handler:           mux,
+			ReadHeaderTimeout: readHeaderTimeout * time.Second,
+		},
+	}
+}
+
+func (metricsServer *MetricsServer) Run() error {
+	log.Println("Metrics server listening on", metricsServer.Addr)
+	err := metricsServer.Server.ListenAndServe()
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
+
+func (metricsServer *MetricsServer) Stop(ctx context.Context) error {
+	err := metricsServer.Server.Shutdown(ctx)
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return metricsServer.Server.Close()
+	}
+
+	return err
+}
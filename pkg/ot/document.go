@@ -0,0 +1,71 @@
+package ot
+
+import (
+	"errors"
+	"fmt"
+)
+
+// maxHistory bounds the operations kept for transforming late edits. Clients
+// further behind than that have to reload the document.
+const maxHistory = 1000
+
+var ErrRevisionTooOld = errors.New("ot: revision is no longer in the history")
+
+// Document is the server side copy of a shared document. Every accepted
+// operation bumps the revision by one. It is not safe for concurrent use.
+type Document struct {
+	text     string
+	base     int
+	history  []Operation
+	revision int
+}
+
+func NewDocument(text string) *Document {
+	return &Document{text: text}
+}
+
+func (d *Document) Text() string {
+	return d.text
+}
+
+func (d *Document) Revision() int {
+	return d.revision
+}
+
+// Apply transforms an operation made against revision over everything
+// accepted since, applies it and returns the transformed operation that other
+// clients have to apply.
+func (d *Document) Apply(revision int, op Operation) (Operation, error) {
+	if revision < d.base || revision > d.revision {
+		return nil, fmt.Errorf("%w: %d", ErrRevisionTooOld, revision)
+	}
+
+	err := op.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	op = op.Normalize()
+	for _, concurrent := range d.history[revision-d.base:] {
+		op, _, err = Transform(op, concurrent)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	text, err := op.Apply(d.text)
+	if err != nil {
+		return nil, err
+	}
+
+	d.text = text
+	d.revision++
+	d.history = append(d.history, op)
+	if len(d.history) > maxHistory {
+		drop := len(d.history) - maxHistory
+		d.history = append([]Operation(nil), d.history[drop:]...)
+		d.base += drop
+	}
+
+	return op, nil
+}
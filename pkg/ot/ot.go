@@ -0,0 +1,335 @@
+// Package ot implements operational transformation for plain strings. An
+// operation walks the whole document and is made of retain, insert and delete
+// components; lengths are counted in Unicode code points.
+package ot
+
+import (
+	"errors"
+	"unicode/utf8"
+
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+var (
+	ErrInvalidComponent = errors.New("ot: component must set exactly one of retain, insert or delete")
+	ErrLengthMismatch   = errors.New("ot: operation length does not match the document")
+)
+
+// Component is a single step of an operation. Exactly one field is set.
+type Component struct {
+	Retain int
+	Insert string
+	Delete int
+}
+
+func (c Component) isRetain() bool { return c.Retain > 0 }
+func (c Component) isInsert() bool { return c.Insert != "" }
+func (c Component) isDelete() bool { return c.Delete > 0 }
+
+type Operation []Component
+
+// BaseLen is the length of the document the operation applies to.
+func (o Operation) BaseLen() int {
+	var n int
+	for _, c := range o {
+		n += c.Retain + c.Delete
+	}
+
+	return n
+}
+
+// TargetLen is the length of the document after the operation is applied.
+func (o Operation) TargetLen() int {
+	var n int
+	for _, c := range o {
+		n += c.Retain + utf8.RuneCountInString(c.Insert)
+	}
+
+	return n
+}
+
+// Validate checks that every component is well formed.
+func (o Operation) Validate() error {
+	for _, c := range o {
+		var set int
+		if c.Retain != 0 {
+			set++
+		}
+		if c.Insert != "" {
+			set++
+		}
+		if c.Delete != 0 {
+			set++
+		}
+
+		if set != 1 || c.Retain < 0 || c.Delete < 0 {
+			return ErrInvalidComponent
+		}
+	}
+
+	return nil
+}
+
+// IsNoop reports whether the operation leaves every document unchanged.
+func (o Operation) IsNoop() bool {
+	for _, c := range o {
+		if !c.isRetain() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Normalize merges adjacent components of the same kind and drops empty ones.
+func (o Operation) Normalize() Operation {
+	var res Operation
+	for _, c := range o {
+		switch {
+		case c.isRetain():
+			res = res.retain(c.Retain)
+		case c.isInsert():
+			res = res.insert(c.Insert)
+		case c.isDelete():
+			res = res.delete(c.Delete)
+		}
+	}
+
+	return res
+}
+
+func (o Operation) retain(n int) Operation {
+	if n <= 0 {
+		return o
+	}
+
+	if last := len(o) - 1; last >= 0 && o[last].isRetain() {
+		o[last].Retain += n
+		return o
+	}
+
+	return append(o, Component{Retain: n})
+}
+
+// insert keeps inserts ahead of deletes at the same position so equivalent
+// operations always have the same shape.
+func (o Operation) insert(s string) Operation {
+	if s == "" {
+		return o
+	}
+
+	last := len(o) - 1
+	switch {
+	case last >= 0 && o[last].isInsert():
+		o[last].Insert += s
+	case last >= 0 && o[last].isDelete():
+		if last >= 1 && o[last-1].isInsert() {
+			o[last-1].Insert += s
+		} else {
+			o = append(o, o[last])
+			o[last] = Component{Insert: s}
+		}
+	default:
+		o = append(o, Component{Insert: s})
+	}
+
+	return o
+}
+
+func (o Operation) delete(n int) Operation {
+	if n <= 0 {
+		return o
+	}
+
+	if last := len(o) - 1; last >= 0 && o[last].isDelete() {
+		o[last].Delete += n
+		return o
+	}
+
+	return append(o, Component{Delete: n})
+}
+
+// Apply runs the operation against doc.
+func (o Operation) Apply(doc string) (string, error) {
+	err := o.Validate()
+	if err != nil {
+		return "", err
+	}
+
+	runes := []rune(doc)
+	if o.BaseLen() != len(runes) {
+		return "", ErrLengthMismatch
+	}
+
+	res := make([]rune, 0, o.TargetLen())
+	var pos int
+	for _, c := range o {
+		switch {
+		case c.isRetain():
+			res = append(res, runes[pos:pos+c.Retain]...)
+			pos += c.Retain
+		case c.isInsert():
+			res = append(res, []rune(c.Insert)...)
+		case c.isDelete():
+			pos += c.Delete
+		}
+	}
+
+	return string(res), nil
+}
+
+// Compose returns a single operation with the same effect as applying a and
+// then b.
+func Compose(a, b Operation) (Operation, error) {
+	if a.TargetLen() != b.BaseLen() {
+		return nil, ErrLengthMismatch
+	}
+
+	var res Operation
+	ops1, ops2 := clone(a), clone(b)
+	i1, i2 := 0, 0
+	for i1 < len(ops1) || i2 < len(ops2) {
+		if i1 < len(ops1) && ops1[i1].isDelete() {
+			res = res.delete(ops1[i1].Delete)
+			i1++
+			continue
+		}
+
+		if i2 < len(ops2) && ops2[i2].isInsert() {
+			res = res.insert(ops2[i2].Insert)
+			i2++
+			continue
+		}
+
+		if i1 == len(ops1) || i2 == len(ops2) {
+			return nil, ErrLengthMismatch
+		}
+
+		op1, op2 := &ops1[i1], &ops2[i2]
+		switch {
+		case op1.isRetain() && op2.isRetain():
+			n := min(op1.Retain, op2.Retain)
+			res = res.retain(n)
+			op1.Retain -= n
+			op2.Retain -= n
+		case op1.isInsert() && op2.isDelete():
+			insert := []rune(op1.Insert)
+			n := min(len(insert), op2.Delete)
+			op1.Insert = string(insert[n:])
+			op2.Delete -= n
+		case op1.isInsert() && op2.isRetain():
+			insert := []rune(op1.Insert)
+			n := min(len(insert), op2.Retain)
+			res = res.insert(string(insert[:n]))
+			op1.Insert = string(insert[n:])
+			op2.Retain -= n
+		case op1.isRetain() && op2.isDelete():
+			n := min(op1.Retain, op2.Delete)
+			res = res.delete(n)
+			op1.Retain -= n
+			op2.Delete -= n
+		}
+
+		if isEmpty(*op1) {
+			i1++
+		}
+		if isEmpty(*op2) {
+			i2++
+		}
+	}
+
+	return res, nil
+}
+
+// Transform takes two concurrent operations on the same document and returns
+// a' and b' such that applying a then b' equals applying b then a'. When both
+// insert at the same position, a's text ends up first.
+func Transform(a, b Operation) (Operation, Operation, error) {
+	if a.BaseLen() != b.BaseLen() {
+		return nil, nil, ErrLengthMismatch
+	}
+
+	var aPrime, bPrime Operation
+	ops1, ops2 := clone(a), clone(b)
+	i1, i2 := 0, 0
+	for i1 < len(ops1) || i2 < len(ops2) {
+		if i1 < len(ops1) && ops1[i1].isInsert() {
+			aPrime = aPrime.insert(ops1[i1].Insert)
+			bPrime = bPrime.retain(utf8.RuneCountInString(ops1[i1].Insert))
+			i1++
+			continue
+		}
+
+		if i2 < len(ops2) && ops2[i2].isInsert() {
+			aPrime = aPrime.retain(utf8.RuneCountInString(ops2[i2].Insert))
+			bPrime = bPrime.insert(ops2[i2].Insert)
+			i2++
+			continue
+		}
+
+		if i1 == len(ops1) || i2 == len(ops2) {
+			return nil, nil, ErrLengthMismatch
+		}
+
+		op1, op2 := &ops1[i1], &ops2[i2]
+		switch {
+		case op1.isRetain() && op2.isRetain():
+			n := min(op1.Retain, op2.Retain)
+			aPrime = aPrime.retain(n)
+			bPrime = bPrime.retain(n)
+			op1.Retain -= n
+			op2.Retain -= n
+		case op1.isDelete() && op2.isDelete():
+			n := min(op1.Delete, op2.Delete)
+			op1.Delete -= n
+			op2.Delete -= n
+		case op1.isDelete() && op2.isRetain():
+			n := min(op1.Delete, op2.Retain)
+			aPrime = aPrime.delete(n)
+			op1.Delete -= n
+			op2.Retain -= n
+		case op1.isRetain() && op2.isDelete():
+			n := min(op1.Retain, op2.Delete)
+			bPrime = bPrime.delete(n)
+			op1.Retain -= n
+			op2.Delete -= n
+		}
+
+		if isEmpty(*op1) {
+			i1++
+		}
+		if isEmpty(*op2) {
+			i2++
+		}
+	}
+
+	return aPrime, bPrime, nil
+}
+
+// FromDiff builds an operation that turns a into b.
+func FromDiff(a, b string) Operation {
+	dmp := diffmatchpatch.New()
+
+	var res Operation
+	for _, diff := range dmp.DiffMain(a, b, false) {
+		switch diff.Type {
+		case diffmatchpatch.DiffEqual:
+			res = res.retain(utf8.RuneCountInString(diff.Text))
+		case diffmatchpatch.DiffInsert:
+			res = res.insert(diff.Text)
+		case diffmatchpatch.DiffDelete:
+			res = res.delete(utf8.RuneCountInString(diff.Text))
+		}
+	}
+
+	return res
+}
+
+// clone returns a normalized copy that can be consumed in place.
+func clone(o Operation) Operation {
+	return o.Normalize()
+}
+
+func isEmpty(c Component) bool {
+	return c.Retain == 0 && c.Insert == "" && c.Delete == 0
+}
@@ -0,0 +1,197 @@
+package ot
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+	"unicode/utf8"
+)
+
+// alphabet mixes ASCII with multi-byte runes so lengths counted in bytes
+// instead of code points show up as failures.
+var alphabet = []rune("abc <>/é漢🙂")
+
+func randomString(r *rand.Rand, n int) string {
+	s := make([]rune, n)
+	for i := range s {
+		s[i] = alphabet[r.Intn(len(alphabet))]
+	}
+
+	return string(s)
+}
+
+// randomOperation builds an operation that applies to a document of length n.
+func randomOperation(r *rand.Rand, n int) Operation {
+	var op Operation
+	for n > 0 {
+		k := 1 + r.Intn(n)
+		switch r.Intn(3) {
+		case 0:
+			op = append(op, Component{Retain: k})
+			n -= k
+		case 1:
+			op = append(op, Component{Delete: k})
+			n -= k
+		default:
+			op = append(op, Component{Insert: randomString(r, 1+r.Intn(4))})
+		}
+	}
+
+	if r.Intn(2) == 0 {
+		op = append(op, Component{Insert: randomString(r, 1+r.Intn(4))})
+	}
+
+	return op
+}
+
+// concurrent is a document with two operations made against it.
+type concurrent struct {
+	Doc  string
+	A, B Operation
+}
+
+func (concurrent) Generate(r *rand.Rand, size int) reflect.Value {
+	doc := randomString(r, r.Intn(size+1))
+	n := utf8.RuneCountInString(doc)
+
+	return reflect.ValueOf(concurrent{
+		Doc: doc,
+		A:   randomOperation(r, n),
+		B:   randomOperation(r, n),
+	})
+}
+
+func mustApply(t *testing.T, op Operation, doc string) string {
+	t.Helper()
+
+	res, err := op.Apply(doc)
+	if err != nil {
+		t.Fatalf("apply %v to %q: %v", op, doc, err)
+	}
+
+	return res
+}
+
+func TestApplyLengths(t *testing.T) {
+	f := func(c concurrent) bool {
+		if c.A.BaseLen() != utf8.RuneCountInString(c.Doc) {
+			return false
+		}
+
+		return utf8.RuneCountInString(mustApply(t, c.A, c.Doc)) == c.A.TargetLen()
+	}
+
+	err := quick.Check(f, nil)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestApplyLengthMismatch(t *testing.T) {
+	_, err := Operation{{Retain: 2}}.Apply("abc")
+	if err != ErrLengthMismatch {
+		t.Errorf("got %v, want %v", err, ErrLengthMismatch)
+	}
+}
+
+func TestNormalizeKeepsEffect(t *testing.T) {
+	f := func(c concurrent) bool {
+		return mustApply(t, c.A.Normalize(), c.Doc) == mustApply(t, c.A, c.Doc)
+	}
+
+	err := quick.Check(f, nil)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestTransformConverges(t *testing.T) {
+	f := func(c concurrent) bool {
+		aPrime, bPrime, err := Transform(c.A, c.B)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		left := mustApply(t, bPrime, mustApply(t, c.A, c.Doc))
+		right := mustApply(t, aPrime, mustApply(t, c.B, c.Doc))
+
+		return left == right
+	}
+
+	err := quick.Check(f, nil)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestComposeMatchesSequentialApply(t *testing.T) {
+	f := func(c concurrent, seed int64) bool {
+		doc := mustApply(t, c.A, c.Doc)
+		b := randomOperation(rand.New(rand.NewSource(seed)), utf8.RuneCountInString(doc))
+
+		ab, err := Compose(c.A, b)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return mustApply(t, ab, c.Doc) == mustApply(t, b, doc)
+	}
+
+	err := quick.Check(f, nil)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFromDiff(t *testing.T) {
+	f := func(a, b concurrent) bool {
+		return mustApply(t, FromDiff(a.Doc, b.Doc), a.Doc) == b.Doc
+	}
+
+	err := quick.Check(f, nil)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDocumentApplyConverges(t *testing.T) {
+	f := func(c concurrent) bool {
+		d := NewDocument(c.Doc)
+
+		a, err := d.Apply(0, c.A)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// B was made against revision 0 too and has to be moved past A.
+		b, err := d.Apply(0, c.B)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// The client that sent B applies A transformed over its own edit.
+		_, aPrime, err := Transform(c.B, a)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return d.Revision() == 2 &&
+			mustApply(t, b, mustApply(t, a, c.Doc)) == d.Text() &&
+			mustApply(t, aPrime, mustApply(t, c.B, c.Doc)) == d.Text()
+	}
+
+	err := quick.Check(f, nil)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDocumentRejectsUnknownRevision(t *testing.T) {
+	d := NewDocument("abc")
+
+	_, err := d.Apply(1, Operation{{Retain: 3}})
+	if err == nil {
+		t.Fatal("expected an error for a revision ahead of the document")
+	}
+}
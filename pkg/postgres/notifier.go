@@ -0,0 +1,120 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const (
+	_defaultMinReconnectInterval = 10 * time.Second
+	_defaultMaxReconnectInterval = time.Minute
+)
+
+// Notifier listens on a Postgres NOTIFY channel and wakes up every in-process
+// subscriber registered for the notification payload.
+type Notifier struct {
+	listener    *pq.Listener
+	mu          sync.Mutex
+	subscribers map[string]map[chan struct{}]struct{}
+}
+
+func NewNotifier(url, channel string) (*Notifier, error) {
+	listener := pq.NewListener(url, _defaultMinReconnectInterval, _defaultMaxReconnectInterval, func(ev pq.ListenerEventType, err error) {
+		if err != nil {
+			log.Printf("postgres - notifier - listener event %d: %v", ev, err)
+		}
+	})
+
+	err := listener.Listen(channel)
+	if err != nil {
+		listener.Close()
+		return nil, fmt.Errorf("postgres - notifier - listener.Listen: %v", err)
+	}
+
+	return &Notifier{
+		listener:    listener,
+		subscribers: make(map[string]map[chan struct{}]struct{}),
+	}, nil
+}
+
+// Run dispatches notifications until ctx is done. A nil notification means the
+// connection was re-established and notifications may have been lost, so every
+// subscriber is woken up to catch up on its own.
+func (n *Notifier) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case notification, ok := <-n.listener.Notify:
+			if !ok {
+				return
+			}
+
+			if notification == nil {
+				n.broadcast()
+				continue
+			}
+
+			n.wake(notification.Extra)
+		}
+	}
+}
+
+// Subscribe returns a channel that receives a signal whenever a notification
+// with the given payload arrives, and a function to cancel the subscription.
+func (n *Notifier) Subscribe(key string) (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+
+	n.mu.Lock()
+	if n.subscribers[key] == nil {
+		n.subscribers[key] = make(map[chan struct{}]struct{})
+	}
+	n.subscribers[key][ch] = struct{}{}
+	n.mu.Unlock()
+
+	return ch, func() {
+		n.mu.Lock()
+		defer n.mu.Unlock()
+
+		delete(n.subscribers[key], ch)
+		if len(n.subscribers[key]) == 0 {
+			delete(n.subscribers, key)
+		}
+	}
+}
+
+func (n *Notifier) Close() error {
+	return n.listener.Close()
+}
+
+func (n *Notifier) wake(key string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for ch := range n.subscribers[key] {
+		signal(ch)
+	}
+}
+
+func (n *Notifier) broadcast() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for _, subscribers := range n.subscribers {
+		for ch := range subscribers {
+			signal(ch)
+		}
+	}
+}
+
+func signal(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,54 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.25.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/digisata/todo-service/pkg/postgres"
+
+var queryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: "todo",
+	Subsystem: "db",
+	Name:      "query_duration_seconds",
+	Help:      "Time spent in a repository method, transactions included.",
+	Buckets:   prometheus.DefBuckets,
+}, []string{"repository", "method"})
+
+// ObserveQuery starts timing a repository method and opens a client span
+// named after it. Defer the returned func with a pointer to the method's error
+// result, so a failed query marks the span as failed:
+//
+//	func (r TaskRepository) GetByID(ctx context.Context, id string) (_ entity.Task, err error) {
+//		defer r.ObserveQuery(ctx, "task", "GetByID", &err)()
+func (p *Postgres) ObserveQuery(ctx context.Context, repository, method string, err *error) func() {
+	start := time.Now()
+
+	_, span := otel.Tracer(tracerName).Start(ctx, repository+"."+method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemPostgreSQL,
+			semconv.DBOperation(method),
+			attribute.String("db.repository", repository),
+			attribute.String("db.statement.name", repository+"."+method),
+		),
+	)
+
+	return func() {
+		if *err != nil {
+			span.RecordError(*err)
+			span.SetStatus(codes.Error, (*err).Error())
+		}
+
+		span.End()
+		queryDuration.WithLabelValues(repository, method).Observe(time.Since(start).Seconds())
+	}
+}
@@ -0,0 +1,56 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestObserveQueryRecordsError(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	query := func(fail error) (err error) {
+		defer (&Postgres{}).ObserveQuery(context.Background(), "task", "GetByID", &err)()
+
+		return fail
+	}
+
+	query(nil)
+	query(errors.New("connection reset"))
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, want 2", len(spans))
+	}
+
+	want := attribute.String("db.statement.name", "task.GetByID")
+	for _, span := range spans {
+		found := false
+		for _, attr := range span.Attributes() {
+			found = found || attr == want
+		}
+
+		if !found {
+			t.Errorf("span %q has no %v attribute", span.Name(), want)
+		}
+	}
+
+	if spans[0].Status().Code != codes.Unset || len(spans[0].Events()) != 0 {
+		t.Errorf("successful query: status %v with %d events", spans[0].Status(), len(spans[0].Events()))
+	}
+
+	if spans[1].Status().Code != codes.Error || spans[1].Status().Description != "connection reset" {
+		t.Errorf("failed query: status %v", spans[1].Status())
+	}
+
+	if len(spans[1].Events()) != 1 || spans[1].Events()[0].Name != "exception" {
+		t.Errorf("failed query: error not recorded, events %v", spans[1].Events())
+	}
+}
@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"time"
@@ -65,3 +66,8 @@ func (p *Postgres) Close() {
 		p.Db.Close()
 	}
 }
+
+// Ping checks that the database can still be reached.
+func (p *Postgres) Ping(ctx context.Context) error {
+	return p.Db.PingContext(ctx)
+}
@@ -0,0 +1,165 @@
+package ratelimit
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	KeyUser   = "user"
+	KeyIP     = "ip"
+	KeyMethod = "method"
+
+	idleTimeout   = 10 * time.Minute
+	sweepInterval = time.Minute
+)
+
+type (
+	Config struct {
+		Enabled bool   `mapstructure:"enabled"`
+		Rules   []Rule `mapstructure:"rules"`
+	}
+
+	// Rule is one token bucket per user, per client IP or per method. Calls
+	// without a user fall back to their IP for user rules.
+	Rule struct {
+		Key string `mapstructure:"key"`
+		// Method is a full method like /proto.TaskService/Create or a
+		// prefix ending with a slash like /proto.TransferService/; every
+		// method when empty.
+		Method string `mapstructure:"method"`
+		// Rate is the number of calls per second, Burst the number of calls
+		// allowed at once.
+		Rate  float64 `mapstructure:"rate"`
+		Burst int     `mapstructure:"burst"`
+	}
+
+	// Caller identifies who makes a call.
+	Caller struct {
+		Method string
+		UserID string
+		IP     string
+	}
+
+	// Limiter holds the buckets of every rule. Buckets unused for a while
+	// are dropped, so it does not grow with the number of clients seen.
+	Limiter struct {
+		rules []Rule
+
+		mu        sync.Mutex
+		buckets   map[string]*bucket
+		lastSweep time.Time
+	}
+
+	bucket struct {
+		limiter  *rate.Limiter
+		lastSeen time.Time
+	}
+)
+
+func New(cfg Config) (*Limiter, error) {
+	for _, rule := range cfg.Rules {
+		switch rule.Key {
+		case KeyUser, KeyIP, KeyMethod:
+		default:
+			return nil, fmt.Errorf("ratelimit - New: unknown key %q", rule.Key)
+		}
+
+		if rule.Rate <= 0 || rule.Burst <= 0 {
+			return nil, fmt.Errorf("ratelimit - New: rate and burst of %s rules must be positive", rule.Key)
+		}
+	}
+
+	return &Limiter{
+		rules:     cfg.Rules,
+		buckets:   make(map[string]*bucket),
+		lastSweep: time.Now(),
+	}, nil
+}
+
+// Allow takes a token from every bucket the call falls in. When one is
+// empty nothing is taken and the time until the call would be allowed is
+// returned.
+func (l *Limiter) Allow(caller Caller) (bool, time.Duration) {
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweep(now)
+
+	var reservations []*rate.Reservation
+	for i, rule := range l.rules {
+		if !rule.matches(caller.Method) {
+			continue
+		}
+
+		key := fmt.Sprintf("%d|%s", i, rule.bucketKey(caller))
+		b, ok := l.buckets[key]
+		if !ok {
+			b = &bucket{limiter: rate.NewLimiter(rate.Limit(rule.Rate), rule.Burst)}
+			l.buckets[key] = b
+		}
+		b.lastSeen = now
+
+		reservation := b.limiter.ReserveN(now, 1)
+		delay := reservation.DelayFrom(now)
+		if delay > 0 {
+			reservation.CancelAt(now)
+			for _, r := range reservations {
+				r.CancelAt(now)
+			}
+
+			return false, delay
+		}
+
+		reservations = append(reservations, reservation)
+	}
+
+	return true, 0
+}
+
+// sweep must be called with l.mu held.
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+
+	l.lastSweep = now
+	for key, b := range l.buckets {
+		if now.Sub(b.lastSeen) > idleTimeout {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+func (rule Rule) matches(method string) bool {
+	if rule.Method == "" {
+		return true
+	}
+
+	if strings.HasSuffix(rule.Method, "/") {
+		return strings.HasPrefix(method, rule.Method)
+	}
+
+	return method == rule.Method
+}
+
+func (rule Rule) bucketKey(caller Caller) string {
+	switch rule.Key {
+	case KeyUser:
+		if caller.UserID != "" {
+			return "user:" + caller.UserID
+		}
+
+		return "ip:" + caller.IP
+	case KeyIP:
+		return "ip:" + caller.IP
+	default:
+		return "method:" + caller.Method
+	}
+}
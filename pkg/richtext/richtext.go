@@ -0,0 +1,67 @@
+package richtext
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+)
+
+var (
+	// policy is the allowlist applied to every stored note. It is the UGC
+	// policy plus plain class names so the frontend can keep its own styling.
+	policy = newPolicy()
+
+	stripPolicy = bluemonday.StrictPolicy()
+	markdown    = goldmark.New(goldmark.WithExtensions(extension.GFM))
+	blockTag    = regexp.MustCompile(`(?i)<\s*(br|/p|/div|/li|/h[1-6]|/tr|/blockquote|/pre)\s*/?>`)
+	whitespace  = regexp.MustCompile(`[ \t\r\f\v]+`)
+	blankLines  = regexp.MustCompile(`\n\s*\n+`)
+)
+
+func newPolicy() *bluemonday.Policy {
+	p := bluemonday.UGCPolicy()
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^[a-zA-Z0-9_\- ]+$`)).Globally()
+	p.AllowAttrs("type").Matching(regexp.MustCompile(`^checkbox$`)).OnElements("input")
+	p.AllowAttrs("checked", "disabled").OnElements("input")
+
+	return p
+}
+
+// Sanitize removes every element and attribute that is not on the allowlist.
+func Sanitize(s string) string {
+	return policy.Sanitize(s)
+}
+
+// FromMarkdown converts Markdown into sanitized HTML.
+func FromMarkdown(s string) (string, error) {
+	var buf bytes.Buffer
+
+	err := markdown.Convert([]byte(s), &buf)
+	if err != nil {
+		return "", fmt.Errorf("richtext - markdown.Convert: %v", err)
+	}
+
+	return Sanitize(buf.String()), nil
+}
+
+// PlainText projects HTML into text suitable for search and previews, keeping
+// block boundaries as line breaks.
+func PlainText(s string) string {
+	s = blockTag.ReplaceAllString(s, "$0\n")
+	s = html.UnescapeString(stripPolicy.Sanitize(s))
+	s = whitespace.ReplaceAllString(s, " ")
+	s = blankLines.ReplaceAllString(s, "\n")
+
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
@@ -3,13 +3,26 @@
 # Install dependencies
 go install google.golang.org/protobuf/cmd/protoc-gen-go@latest
 go install google.golang.org/grpc/cmd/protoc-gen-go-grpc@latest
+go install github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-grpc-gateway@v2.20.0
+go install github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-openapiv2@v2.20.0
 
 # Generate Stubs
 protoc -I ./proto \
   --proto_path ./proto/ \
+  --proto_path ./third_party/googleapis/ \
   --plugin=$(go env GOPATH)/bin/protoc-gen-go \
   --plugin=$(go env GOPATH)/bin/protoc-gen-go-grpc \
+  --plugin=$(go env GOPATH)/bin/protoc-gen-grpc-gateway \
   --go_out ./stubs --go_opt paths=source_relative \
   --go-grpc_out ./stubs --go-grpc_opt paths=source_relative \
+  --grpc-gateway_out ./stubs --grpc-gateway_opt paths=source_relative \
   ./proto/*/*.proto
 
+# Generate the OpenAPI document of the services served over HTTP
+protoc -I ./proto \
+  --proto_path ./proto/ \
+  --proto_path ./third_party/googleapis/ \
+  --plugin=$(go env GOPATH)/bin/protoc-gen-openapiv2 \
+  --openapiv2_out ./docs/openapi \
+  --openapiv2_opt allow_merge=true,merge_file_name=todo-service,openapi_configuration=./proto/openapi.yaml \
+  ./proto/activity/*.proto ./proto/task/*.proto ./proto/text/*.proto
@@ -19,11 +19,15 @@ import (
 const _ = grpc.SupportPackageIsVersion8
 
 const (
-	ActivityService_Create_FullMethodName = "/proto.ActivityService/Create"
-	ActivityService_Get_FullMethodName    = "/proto.ActivityService/Get"
-	ActivityService_GetAll_FullMethodName = "/proto.ActivityService/GetAll"
-	ActivityService_Update_FullMethodName = "/proto.ActivityService/Update"
-	ActivityService_Delete_FullMethodName = "/proto.ActivityService/Delete"
+	ActivityService_Create_FullMethodName      = "/proto.ActivityService/Create"
+	ActivityService_Get_FullMethodName         = "/proto.ActivityService/Get"
+	ActivityService_GetAll_FullMethodName      = "/proto.ActivityService/GetAll"
+	ActivityService_Update_FullMethodName      = "/proto.ActivityService/Update"
+	ActivityService_Delete_FullMethodName      = "/proto.ActivityService/Delete"
+	ActivityService_GetAllTypes_FullMethodName = "/proto.ActivityService/GetAllTypes"
+	ActivityService_Archive_FullMethodName     = "/proto.ActivityService/Archive"
+	ActivityService_Unarchive_FullMethodName   = "/proto.ActivityService/Unarchive"
+	ActivityService_Watch_FullMethodName       = "/proto.ActivityService/Watch"
 )
 
 // ActivityServiceClient is the client API for ActivityService service.
@@ -35,6 +39,10 @@ type ActivityServiceClient interface {
 	GetAll(ctx context.Context, in *GetAllActivityRequest, opts ...grpc.CallOption) (*GetAllActivityResponse, error)
 	Update(ctx context.Context, in *UpdateActivityByIDRequest, opts ...grpc.CallOption) (*ActivityBaseResponse, error)
 	Delete(ctx context.Context, in *DeleteActivityByIDRequest, opts ...grpc.CallOption) (*ActivityBaseResponse, error)
+	GetAllTypes(ctx context.Context, in *GetAllActivityTypeRequest, opts ...grpc.CallOption) (*GetAllActivityTypeResponse, error)
+	Archive(ctx context.Context, in *ArchiveActivityByIDRequest, opts ...grpc.CallOption) (*ActivityBaseResponse, error)
+	Unarchive(ctx context.Context, in *UnarchiveActivityByIDRequest, opts ...grpc.CallOption) (*ActivityBaseResponse, error)
+	Watch(ctx context.Context, in *WatchActivityRequest, opts ...grpc.CallOption) (ActivityService_WatchClient, error)
 }
 
 type activityServiceClient struct {
@@ -95,6 +103,69 @@ func (c *activityServiceClient) Delete(ctx context.Context, in *DeleteActivityBy
 	return out, nil
 }
 
+func (c *activityServiceClient) GetAllTypes(ctx context.Context, in *GetAllActivityTypeRequest, opts ...grpc.CallOption) (*GetAllActivityTypeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAllActivityTypeResponse)
+	err := c.cc.Invoke(ctx, ActivityService_GetAllTypes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *activityServiceClient) Archive(ctx context.Context, in *ArchiveActivityByIDRequest, opts ...grpc.CallOption) (*ActivityBaseResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ActivityBaseResponse)
+	err := c.cc.Invoke(ctx, ActivityService_Archive_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *activityServiceClient) Unarchive(ctx context.Context, in *UnarchiveActivityByIDRequest, opts ...grpc.CallOption) (*ActivityBaseResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ActivityBaseResponse)
+	err := c.cc.Invoke(ctx, ActivityService_Unarchive_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *activityServiceClient) Watch(ctx context.Context, in *WatchActivityRequest, opts ...grpc.CallOption) (ActivityService_WatchClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ActivityService_ServiceDesc.Streams[0], ActivityService_Watch_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &activityServiceWatchClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ActivityService_WatchClient interface {
+	Recv() (*ActivityEvent, error)
+	grpc.ClientStream
+}
+
+type activityServiceWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *activityServiceWatchClient) Recv() (*ActivityEvent, error) {
+	m := new(ActivityEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // ActivityServiceServer is the server API for ActivityService service.
 // All implementations must embed UnimplementedActivityServiceServer
 // for forward compatibility
@@ -104,6 +175,10 @@ type ActivityServiceServer interface {
 	GetAll(context.Context, *GetAllActivityRequest) (*GetAllActivityResponse, error)
 	Update(context.Context, *UpdateActivityByIDRequest) (*ActivityBaseResponse, error)
 	Delete(context.Context, *DeleteActivityByIDRequest) (*ActivityBaseResponse, error)
+	GetAllTypes(context.Context, *GetAllActivityTypeRequest) (*GetAllActivityTypeResponse, error)
+	Archive(context.Context, *ArchiveActivityByIDRequest) (*ActivityBaseResponse, error)
+	Unarchive(context.Context, *UnarchiveActivityByIDRequest) (*ActivityBaseResponse, error)
+	Watch(*WatchActivityRequest, ActivityService_WatchServer) error
 	mustEmbedUnimplementedActivityServiceServer()
 }
 
@@ -126,6 +201,18 @@ func (UnimplementedActivityServiceServer) Update(context.Context, *UpdateActivit
 func (UnimplementedActivityServiceServer) Delete(context.Context, *DeleteActivityByIDRequest) (*ActivityBaseResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
 }
+func (UnimplementedActivityServiceServer) GetAllTypes(context.Context, *GetAllActivityTypeRequest) (*GetAllActivityTypeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAllTypes not implemented")
+}
+func (UnimplementedActivityServiceServer) Archive(context.Context, *ArchiveActivityByIDRequest) (*ActivityBaseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Archive not implemented")
+}
+func (UnimplementedActivityServiceServer) Unarchive(context.Context, *UnarchiveActivityByIDRequest) (*ActivityBaseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Unarchive not implemented")
+}
+func (UnimplementedActivityServiceServer) Watch(*WatchActivityRequest, ActivityService_WatchServer) error {
+	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
 func (UnimplementedActivityServiceServer) mustEmbedUnimplementedActivityServiceServer() {}
 
 // UnsafeActivityServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -229,6 +316,81 @@ func _ActivityService_Delete_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ActivityService_GetAllTypes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAllActivityTypeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ActivityServiceServer).GetAllTypes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ActivityService_GetAllTypes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ActivityServiceServer).GetAllTypes(ctx, req.(*GetAllActivityTypeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ActivityService_Archive_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ArchiveActivityByIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ActivityServiceServer).Archive(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ActivityService_Archive_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ActivityServiceServer).Archive(ctx, req.(*ArchiveActivityByIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ActivityService_Unarchive_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnarchiveActivityByIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ActivityServiceServer).Unarchive(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ActivityService_Unarchive_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ActivityServiceServer).Unarchive(ctx, req.(*UnarchiveActivityByIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ActivityService_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchActivityRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ActivityServiceServer).Watch(m, &activityServiceWatchServer{ServerStream: stream})
+}
+
+type ActivityService_WatchServer interface {
+	Send(*ActivityEvent) error
+	grpc.ServerStream
+}
+
+type activityServiceWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *activityServiceWatchServer) Send(m *ActivityEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // ActivityService_ServiceDesc is the grpc.ServiceDesc for ActivityService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -256,7 +418,25 @@ var ActivityService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Delete",
 			Handler:    _ActivityService_Delete_Handler,
 		},
+		{
+			MethodName: "GetAllTypes",
+			Handler:    _ActivityService_GetAllTypes_Handler,
+		},
+		{
+			MethodName: "Archive",
+			Handler:    _ActivityService_Archive_Handler,
+		},
+		{
+			MethodName: "Unarchive",
+			Handler:    _ActivityService_Unarchive_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Watch",
+			Handler:       _ActivityService_Watch_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "activity/activity_service.proto",
 }
@@ -19,12 +19,17 @@ import (
 const _ = grpc.SupportPackageIsVersion8
 
 const (
-	TaskService_Create_FullMethodName         = "/proto.TaskService/Create"
-	TaskService_Get_FullMethodName            = "/proto.TaskService/Get"
-	TaskService_GetAllByUserID_FullMethodName = "/proto.TaskService/GetAllByUserID"
-	TaskService_Update_FullMethodName         = "/proto.TaskService/Update"
-	TaskService_Delete_FullMethodName         = "/proto.TaskService/Delete"
-	TaskService_BatchUpdate_FullMethodName    = "/proto.TaskService/BatchUpdate"
+	TaskService_Create_FullMethodName                = "/proto.TaskService/Create"
+	TaskService_Get_FullMethodName                   = "/proto.TaskService/Get"
+	TaskService_GetAllByUserID_FullMethodName        = "/proto.TaskService/GetAllByUserID"
+	TaskService_Update_FullMethodName                = "/proto.TaskService/Update"
+	TaskService_Delete_FullMethodName                = "/proto.TaskService/Delete"
+	TaskService_BatchUpdate_FullMethodName           = "/proto.TaskService/BatchUpdate"
+	TaskService_ArchiveCompletedTasks_FullMethodName = "/proto.TaskService/ArchiveCompletedTasks"
+	TaskService_BulkCreate_FullMethodName            = "/proto.TaskService/BulkCreate"
+	TaskService_BulkDelete_FullMethodName            = "/proto.TaskService/BulkDelete"
+	TaskService_BulkComplete_FullMethodName          = "/proto.TaskService/BulkComplete"
+	TaskService_BulkMove_FullMethodName              = "/proto.TaskService/BulkMove"
 )
 
 // TaskServiceClient is the client API for TaskService service.
@@ -37,6 +42,11 @@ type TaskServiceClient interface {
 	Update(ctx context.Context, in *UpdateTaskByIDRequest, opts ...grpc.CallOption) (*TaskBaseResponse, error)
 	Delete(ctx context.Context, in *DeleteTaskByIDRequest, opts ...grpc.CallOption) (*TaskBaseResponse, error)
 	BatchUpdate(ctx context.Context, in *BatchUpdateTaskRequest, opts ...grpc.CallOption) (*TaskBaseResponse, error)
+	ArchiveCompletedTasks(ctx context.Context, in *ArchiveCompletedTasksRequest, opts ...grpc.CallOption) (*ArchiveCompletedTasksResponse, error)
+	BulkCreate(ctx context.Context, in *BulkCreateTaskRequest, opts ...grpc.CallOption) (*BulkTaskResponse, error)
+	BulkDelete(ctx context.Context, in *BulkTaskByIDsRequest, opts ...grpc.CallOption) (*BulkTaskResponse, error)
+	BulkComplete(ctx context.Context, in *BulkTaskByIDsRequest, opts ...grpc.CallOption) (*BulkTaskResponse, error)
+	BulkMove(ctx context.Context, in *BulkMoveTaskRequest, opts ...grpc.CallOption) (*BulkTaskResponse, error)
 }
 
 type taskServiceClient struct {
@@ -107,6 +117,56 @@ func (c *taskServiceClient) BatchUpdate(ctx context.Context, in *BatchUpdateTask
 	return out, nil
 }
 
+func (c *taskServiceClient) ArchiveCompletedTasks(ctx context.Context, in *ArchiveCompletedTasksRequest, opts ...grpc.CallOption) (*ArchiveCompletedTasksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ArchiveCompletedTasksResponse)
+	err := c.cc.Invoke(ctx, TaskService_ArchiveCompletedTasks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) BulkCreate(ctx context.Context, in *BulkCreateTaskRequest, opts ...grpc.CallOption) (*BulkTaskResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BulkTaskResponse)
+	err := c.cc.Invoke(ctx, TaskService_BulkCreate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) BulkDelete(ctx context.Context, in *BulkTaskByIDsRequest, opts ...grpc.CallOption) (*BulkTaskResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BulkTaskResponse)
+	err := c.cc.Invoke(ctx, TaskService_BulkDelete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) BulkComplete(ctx context.Context, in *BulkTaskByIDsRequest, opts ...grpc.CallOption) (*BulkTaskResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BulkTaskResponse)
+	err := c.cc.Invoke(ctx, TaskService_BulkComplete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) BulkMove(ctx context.Context, in *BulkMoveTaskRequest, opts ...grpc.CallOption) (*BulkTaskResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BulkTaskResponse)
+	err := c.cc.Invoke(ctx, TaskService_BulkMove_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TaskServiceServer is the server API for TaskService service.
 // All implementations must embed UnimplementedTaskServiceServer
 // for forward compatibility
@@ -117,6 +177,11 @@ type TaskServiceServer interface {
 	Update(context.Context, *UpdateTaskByIDRequest) (*TaskBaseResponse, error)
 	Delete(context.Context, *DeleteTaskByIDRequest) (*TaskBaseResponse, error)
 	BatchUpdate(context.Context, *BatchUpdateTaskRequest) (*TaskBaseResponse, error)
+	ArchiveCompletedTasks(context.Context, *ArchiveCompletedTasksRequest) (*ArchiveCompletedTasksResponse, error)
+	BulkCreate(context.Context, *BulkCreateTaskRequest) (*BulkTaskResponse, error)
+	BulkDelete(context.Context, *BulkTaskByIDsRequest) (*BulkTaskResponse, error)
+	BulkComplete(context.Context, *BulkTaskByIDsRequest) (*BulkTaskResponse, error)
+	BulkMove(context.Context, *BulkMoveTaskRequest) (*BulkTaskResponse, error)
 	mustEmbedUnimplementedTaskServiceServer()
 }
 
@@ -142,6 +207,21 @@ func (UnimplementedTaskServiceServer) Delete(context.Context, *DeleteTaskByIDReq
 func (UnimplementedTaskServiceServer) BatchUpdate(context.Context, *BatchUpdateTaskRequest) (*TaskBaseResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method BatchUpdate not implemented")
 }
+func (UnimplementedTaskServiceServer) ArchiveCompletedTasks(context.Context, *ArchiveCompletedTasksRequest) (*ArchiveCompletedTasksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ArchiveCompletedTasks not implemented")
+}
+func (UnimplementedTaskServiceServer) BulkCreate(context.Context, *BulkCreateTaskRequest) (*BulkTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BulkCreate not implemented")
+}
+func (UnimplementedTaskServiceServer) BulkDelete(context.Context, *BulkTaskByIDsRequest) (*BulkTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BulkDelete not implemented")
+}
+func (UnimplementedTaskServiceServer) BulkComplete(context.Context, *BulkTaskByIDsRequest) (*BulkTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BulkComplete not implemented")
+}
+func (UnimplementedTaskServiceServer) BulkMove(context.Context, *BulkMoveTaskRequest) (*BulkTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BulkMove not implemented")
+}
 func (UnimplementedTaskServiceServer) mustEmbedUnimplementedTaskServiceServer() {}
 
 // UnsafeTaskServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -263,6 +343,96 @@ func _TaskService_BatchUpdate_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TaskService_ArchiveCompletedTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ArchiveCompletedTasksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).ArchiveCompletedTasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_ArchiveCompletedTasks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).ArchiveCompletedTasks(ctx, req.(*ArchiveCompletedTasksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_BulkCreate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkCreateTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).BulkCreate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_BulkCreate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).BulkCreate(ctx, req.(*BulkCreateTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_BulkDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkTaskByIDsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).BulkDelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_BulkDelete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).BulkDelete(ctx, req.(*BulkTaskByIDsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_BulkComplete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkTaskByIDsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).BulkComplete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_BulkComplete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).BulkComplete(ctx, req.(*BulkTaskByIDsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_BulkMove_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkMoveTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).BulkMove(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_BulkMove_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).BulkMove(ctx, req.(*BulkMoveTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TaskService_ServiceDesc is the grpc.ServiceDesc for TaskService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -294,6 +464,26 @@ var TaskService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "BatchUpdate",
 			Handler:    _TaskService_BatchUpdate_Handler,
 		},
+		{
+			MethodName: "ArchiveCompletedTasks",
+			Handler:    _TaskService_ArchiveCompletedTasks_Handler,
+		},
+		{
+			MethodName: "BulkCreate",
+			Handler:    _TaskService_BulkCreate_Handler,
+		},
+		{
+			MethodName: "BulkDelete",
+			Handler:    _TaskService_BulkDelete_Handler,
+		},
+		{
+			MethodName: "BulkComplete",
+			Handler:    _TaskService_BulkComplete_Handler,
+		},
+		{
+			MethodName: "BulkMove",
+			Handler:    _TaskService_BulkMove_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "task/task_service.proto",
@@ -19,11 +19,16 @@ import (
 const _ = grpc.SupportPackageIsVersion8
 
 const (
-	TextService_Create_FullMethodName         = "/proto.TextService/Create"
-	TextService_Get_FullMethodName            = "/proto.TextService/Get"
-	TextService_GetAllByUserID_FullMethodName = "/proto.TextService/GetAllByUserID"
-	TextService_Update_FullMethodName         = "/proto.TextService/Update"
-	TextService_Delete_FullMethodName         = "/proto.TextService/Delete"
+	TextService_Create_FullMethodName              = "/proto.TextService/Create"
+	TextService_Get_FullMethodName                 = "/proto.TextService/Get"
+	TextService_GetAllByUserID_FullMethodName      = "/proto.TextService/GetAllByUserID"
+	TextService_Update_FullMethodName              = "/proto.TextService/Update"
+	TextService_Delete_FullMethodName              = "/proto.TextService/Delete"
+	TextService_ListTextRevisions_FullMethodName   = "/proto.TextService/ListTextRevisions"
+	TextService_GetTextRevision_FullMethodName     = "/proto.TextService/GetTextRevision"
+	TextService_DiffTextRevisions_FullMethodName   = "/proto.TextService/DiffTextRevisions"
+	TextService_RestoreTextRevision_FullMethodName = "/proto.TextService/RestoreTextRevision"
+	TextService_CollaborateText_FullMethodName     = "/proto.TextService/CollaborateText"
 )
 
 // TextServiceClient is the client API for TextService service.
@@ -35,6 +40,12 @@ type TextServiceClient interface {
 	GetAllByUserID(ctx context.Context, in *GetAllTextByActivityIDRequest, opts ...grpc.CallOption) (*GetAllTextByActivityIDResponse, error)
 	Update(ctx context.Context, in *UpdateTextByIDRequest, opts ...grpc.CallOption) (*TextBaseResponse, error)
 	Delete(ctx context.Context, in *DeleteTextByIDRequest, opts ...grpc.CallOption) (*TextBaseResponse, error)
+	ListTextRevisions(ctx context.Context, in *ListTextRevisionsRequest, opts ...grpc.CallOption) (*ListTextRevisionsResponse, error)
+	GetTextRevision(ctx context.Context, in *GetTextRevisionRequest, opts ...grpc.CallOption) (*TextRevision, error)
+	DiffTextRevisions(ctx context.Context, in *DiffTextRevisionsRequest, opts ...grpc.CallOption) (*DiffTextRevisionsResponse, error)
+	RestoreTextRevision(ctx context.Context, in *RestoreTextRevisionRequest, opts ...grpc.CallOption) (*TextBaseResponse, error)
+	// Not exposed over HTTP: the gateway cannot carry bidirectional streams.
+	CollaborateText(ctx context.Context, opts ...grpc.CallOption) (TextService_CollaborateTextClient, error)
 }
 
 type textServiceClient struct {
@@ -95,6 +106,78 @@ func (c *textServiceClient) Delete(ctx context.Context, in *DeleteTextByIDReques
 	return out, nil
 }
 
+func (c *textServiceClient) ListTextRevisions(ctx context.Context, in *ListTextRevisionsRequest, opts ...grpc.CallOption) (*ListTextRevisionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTextRevisionsResponse)
+	err := c.cc.Invoke(ctx, TextService_ListTextRevisions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *textServiceClient) GetTextRevision(ctx context.Context, in *GetTextRevisionRequest, opts ...grpc.CallOption) (*TextRevision, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TextRevision)
+	err := c.cc.Invoke(ctx, TextService_GetTextRevision_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *textServiceClient) DiffTextRevisions(ctx context.Context, in *DiffTextRevisionsRequest, opts ...grpc.CallOption) (*DiffTextRevisionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DiffTextRevisionsResponse)
+	err := c.cc.Invoke(ctx, TextService_DiffTextRevisions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *textServiceClient) RestoreTextRevision(ctx context.Context, in *RestoreTextRevisionRequest, opts ...grpc.CallOption) (*TextBaseResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TextBaseResponse)
+	err := c.cc.Invoke(ctx, TextService_RestoreTextRevision_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *textServiceClient) CollaborateText(ctx context.Context, opts ...grpc.CallOption) (TextService_CollaborateTextClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TextService_ServiceDesc.Streams[0], TextService_CollaborateText_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &textServiceCollaborateTextClient{ClientStream: stream}
+	return x, nil
+}
+
+type TextService_CollaborateTextClient interface {
+	Send(*CollaborateTextRequest) error
+	Recv() (*CollaborateTextResponse, error)
+	grpc.ClientStream
+}
+
+type textServiceCollaborateTextClient struct {
+	grpc.ClientStream
+}
+
+func (x *textServiceCollaborateTextClient) Send(m *CollaborateTextRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *textServiceCollaborateTextClient) Recv() (*CollaborateTextResponse, error) {
+	m := new(CollaborateTextResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // TextServiceServer is the server API for TextService service.
 // All implementations must embed UnimplementedTextServiceServer
 // for forward compatibility
@@ -104,6 +187,12 @@ type TextServiceServer interface {
 	GetAllByUserID(context.Context, *GetAllTextByActivityIDRequest) (*GetAllTextByActivityIDResponse, error)
 	Update(context.Context, *UpdateTextByIDRequest) (*TextBaseResponse, error)
 	Delete(context.Context, *DeleteTextByIDRequest) (*TextBaseResponse, error)
+	ListTextRevisions(context.Context, *ListTextRevisionsRequest) (*ListTextRevisionsResponse, error)
+	GetTextRevision(context.Context, *GetTextRevisionRequest) (*TextRevision, error)
+	DiffTextRevisions(context.Context, *DiffTextRevisionsRequest) (*DiffTextRevisionsResponse, error)
+	RestoreTextRevision(context.Context, *RestoreTextRevisionRequest) (*TextBaseResponse, error)
+	// Not exposed over HTTP: the gateway cannot carry bidirectional streams.
+	CollaborateText(TextService_CollaborateTextServer) error
 	mustEmbedUnimplementedTextServiceServer()
 }
 
@@ -126,6 +215,21 @@ func (UnimplementedTextServiceServer) Update(context.Context, *UpdateTextByIDReq
 func (UnimplementedTextServiceServer) Delete(context.Context, *DeleteTextByIDRequest) (*TextBaseResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
 }
+func (UnimplementedTextServiceServer) ListTextRevisions(context.Context, *ListTextRevisionsRequest) (*ListTextRevisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTextRevisions not implemented")
+}
+func (UnimplementedTextServiceServer) GetTextRevision(context.Context, *GetTextRevisionRequest) (*TextRevision, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTextRevision not implemented")
+}
+func (UnimplementedTextServiceServer) DiffTextRevisions(context.Context, *DiffTextRevisionsRequest) (*DiffTextRevisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiffTextRevisions not implemented")
+}
+func (UnimplementedTextServiceServer) RestoreTextRevision(context.Context, *RestoreTextRevisionRequest) (*TextBaseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreTextRevision not implemented")
+}
+func (UnimplementedTextServiceServer) CollaborateText(TextService_CollaborateTextServer) error {
+	return status.Errorf(codes.Unimplemented, "method CollaborateText not implemented")
+}
 func (UnimplementedTextServiceServer) mustEmbedUnimplementedTextServiceServer() {}
 
 // UnsafeTextServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -229,6 +333,104 @@ func _TextService_Delete_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TextService_ListTextRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTextRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TextServiceServer).ListTextRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TextService_ListTextRevisions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TextServiceServer).ListTextRevisions(ctx, req.(*ListTextRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TextService_GetTextRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTextRevisionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TextServiceServer).GetTextRevision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TextService_GetTextRevision_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TextServiceServer).GetTextRevision(ctx, req.(*GetTextRevisionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TextService_DiffTextRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffTextRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TextServiceServer).DiffTextRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TextService_DiffTextRevisions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TextServiceServer).DiffTextRevisions(ctx, req.(*DiffTextRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TextService_RestoreTextRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreTextRevisionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TextServiceServer).RestoreTextRevision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TextService_RestoreTextRevision_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TextServiceServer).RestoreTextRevision(ctx, req.(*RestoreTextRevisionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TextService_CollaborateText_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(TextServiceServer).CollaborateText(&textServiceCollaborateTextServer{ServerStream: stream})
+}
+
+type TextService_CollaborateTextServer interface {
+	Send(*CollaborateTextResponse) error
+	Recv() (*CollaborateTextRequest, error)
+	grpc.ServerStream
+}
+
+type textServiceCollaborateTextServer struct {
+	grpc.ServerStream
+}
+
+func (x *textServiceCollaborateTextServer) Send(m *CollaborateTextResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *textServiceCollaborateTextServer) Recv() (*CollaborateTextRequest, error) {
+	m := new(CollaborateTextRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // TextService_ServiceDesc is the grpc.ServiceDesc for TextService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -256,7 +458,30 @@ var TextService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Delete",
 			Handler:    _TextService_Delete_Handler,
 		},
+		{
+			MethodName: "ListTextRevisions",
+			Handler:    _TextService_ListTextRevisions_Handler,
+		},
+		{
+			MethodName: "GetTextRevision",
+			Handler:    _TextService_GetTextRevision_Handler,
+		},
+		{
+			MethodName: "DiffTextRevisions",
+			Handler:    _TextService_DiffTextRevisions_Handler,
+		},
+		{
+			MethodName: "RestoreTextRevision",
+			Handler:    _TextService_RestoreTextRevision_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "CollaborateText",
+			Handler:       _TextService_CollaborateText_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "text/text_service.proto",
 }